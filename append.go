@@ -0,0 +1,171 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// appendMethods lists names of methods which add element to collection, in
+// order of precedence.
+var appendMethods = []string{"Add", "Append"}
+
+// getAppendMethod returns method of pointer to the value of given type (or
+// of type itself, if it's pointer) which adds element to collection. Method
+// should accept single element and return nothing or error, like
+// `Add(string)` or `Append(int) error`.
+func getAppendMethod(fieldType reflect.Type) (reflect.Method, bool) {
+	if fieldType.Kind() != reflect.Ptr {
+		fieldType = reflect.PtrTo(fieldType)
+	}
+
+	for _, name := range appendMethods {
+		method, ok := fieldType.MethodByName(name)
+		if !ok {
+			continue
+		}
+
+		// First argument is the receiver.
+		if method.Type.NumIn() != 2 {
+			continue
+		}
+
+		switch method.Type.NumOut() {
+		case 0:
+			return method, true
+		case 1:
+			if method.Type.Out(0) == errorType {
+				return method, true
+			}
+		}
+	}
+
+	return reflect.Method{}, false
+}
+
+// bindAppend splits mapped string like slice and adds every element into
+// new collection of the field type using its Add or Append method. Elements
+// are parsed using binding specified as option, like `append:int;sep=|`, or
+// default binding of the method argument type. Elements of interface{} type
+// are added as strings.
+func bindAppend(field Field, opts string) (interface{}, error) {
+	fieldType := field.StructField.Type
+
+	method, ok := getAppendMethod(fieldType)
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"append binding of %s requires type with Add or Append method",
+				field.Name,
+			),
+		)
+	}
+
+	spec, separator, ok := splitSeparatorOption(opts)
+	if !ok {
+		separator = ","
+	}
+
+	elemType := method.Type.In(1)
+
+	if spec == "" {
+		spec = getDefaultBindingTag(elemType)
+	}
+
+	if spec == "" && elemType.Kind() == reflect.Interface &&
+		elemType.NumMethod() == 0 {
+		spec = "string"
+	}
+
+	if spec == "" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"binding for elements of %s is not specified",
+				field.Name,
+			),
+		)
+	}
+
+	elemName, elemOpts := parseBindingTag(reflect.StructField{
+		Tag: reflect.StructTag(`binding:` + strconv.Quote(spec)),
+	})
+
+	binding, ok := field.Bindings[elemName]
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"binding %q for elements of %s is not registered",
+				elemName,
+				field.Name,
+			),
+		)
+	}
+
+	if field.Value == nil {
+		return nil, nil
+	}
+
+	items, ok := getSliceItems(field.Value, separator)
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"only strings are supported, but %T given",
+				field.Value,
+			),
+		)
+	}
+
+	collectionType := fieldType
+	if collectionType.Kind() == reflect.Ptr {
+		collectionType = collectionType.Elem()
+	}
+
+	var (
+		collection = reflect.New(collectionType)
+		errors     BindingErrors
+	)
+
+	for index, item := range items {
+		name := fmt.Sprintf("%s[%d]", field.Name, index)
+
+		value, err := binding(item, elemOpts)
+		if _, ok := err.(InvalidBindingError); ok {
+			return nil, err
+		}
+
+		if err != nil {
+			errors = append(errors, BindingError{name: name, cause: err})
+
+			continue
+		}
+
+		elem := reflect.New(elemType).Elem()
+		if !setValue(elem, value) {
+			return nil, InvalidBindingError(
+				fmt.Sprintf(
+					`binding of %s returned %T, which can't be set`,
+					name,
+					value,
+				),
+			)
+		}
+
+		result := method.Func.Call([]reflect.Value{collection, elem})
+		if len(result) > 0 && !result[0].IsNil() {
+			errors = append(errors, BindingError{
+				name:  name,
+				cause: result[0].Interface().(error),
+			})
+		}
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	if fieldType.Kind() == reflect.Ptr {
+		return collection.Interface(), nil
+	}
+
+	return collection.Elem().Interface(), nil
+}
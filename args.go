@@ -0,0 +1,133 @@
+package binding
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// BindArgs binds command line arguments in the form of `--name value` or
+// `--name=value` into output struct, like Bind does. Flags are matched
+// against field names, including dotted names of nested fields, like
+// `--address.city`. Bool fields (and pointers to bools) can be specified
+// without value, like `--verbose`, which means `true`. Flags for slice fields
+// can be repeated, like `--tag a --tag b`; for other fields the last value is
+// used.
+//
+// Parsing stops at first argument which is not a flag or after `--`, like
+// flag package does. Unknown flags and flags without value are reported as
+// BindingError, unless `IgnoreUnknownArgs(true)` option is passed, which
+// makes BindArgs to skip unknown flags.
+//
+// Options are the same as accepted by Bind, except KeyFunc, since flags are
+// matched against field names, which is reported as InvalidBindingError.
+// Elements of slices of nested structs, like `Items[].Name`, can't be
+// specified using flags.
+func BindArgs(
+	output interface{},
+	args []string,
+	options ...interface{},
+) error {
+	var (
+		config = newConfig(options)
+		types  = map[string]reflect.Type{}
+		values = map[string][]string{}
+		errs   = BindingErrors{}
+	)
+
+	if config.keyFunc != nil {
+		return InvalidBindingError(
+			"KeyFunc option is not supported by BindArgs",
+		)
+	}
+
+	for _, field := range DescribeStruct(reflect.TypeOf(output), options...) {
+		if strings.Contains(field.Name, "[]") {
+			continue
+		}
+
+		types[config.prefix+field.Name] = field.Type
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || !strings.HasPrefix(arg, "--") {
+			break
+		}
+
+		var (
+			pair     = strings.SplitN(strings.TrimPrefix(arg, "--"), "=", 2)
+			name     = pair[0]
+			value    = ""
+			hasValue = len(pair) == 2
+		)
+
+		if hasValue {
+			value = pair[1]
+		}
+
+		fieldType, ok := types[name]
+		if !ok {
+			if !config.ignoreUnknownArgs {
+				errs = append(errs, BindingError{
+					name:  name,
+					cause: errors.New("unknown flag"),
+				})
+			}
+
+			continue
+		}
+
+		if !hasValue {
+			switch {
+			case isBoolType(fieldType):
+				value = "true"
+			case i+1 < len(args):
+				i++
+				value = args[i]
+			default:
+				errs = append(errs, BindingError{
+					name:  name,
+					cause: errors.New("flag needs an argument"),
+				})
+
+				continue
+			}
+		}
+
+		values[name] = append(values[name], value)
+	}
+
+	err := Bind(output, func(name string) interface{} {
+		value, ok := values[name]
+		if !ok {
+			return nil
+		}
+
+		if isSliceType(types[name]) {
+			return value
+		}
+
+		return value[len(value)-1]
+	}, options...)
+
+	if err, ok := err.(BindingErrors); ok {
+		errs = append(errs, err...)
+	} else if err != nil {
+		return err
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func isBoolType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	return fieldType.Kind() == reflect.Bool
+}
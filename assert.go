@@ -0,0 +1,351 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AssertBindable checks that tags of the output struct are consistent
+// without binding any values: bindings are registered, setters and binding
+// methods have valid signatures, `required` tags are valid bools (or lists
+// containing `required`), values returned by bindings can be set, `coerce`
+// tags are well-formed, `minitems` and `maxitems` tags are used only for
+// slices, `oneof` tags are used only for strings, bools and numbers, and
+// default values and `oneof` values can be parsed by field's binding. Nested
+// structs are checked recursively. Fields compared by Compare option should
+// exist and have comparable types.
+//
+// It's intended to be called in tests, so misspelled tags are caught early.
+// Options are the same as accepted by Bind. Output can be either struct or
+// pointer to struct. First found problem is returned as InvalidBindingError.
+func AssertBindable(output interface{}, options ...interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(output))
+	if !value.IsValid() || value.Kind() != reflect.Struct {
+		return InvalidBindingError(
+			fmt.Sprintf(
+				`output should be struct type, but %T is given`,
+				output,
+			),
+		)
+	}
+
+	config := newConfig(options)
+
+	err := assertBindable(value.Type(), config, "", map[reflect.Type]bool{})
+	if err != nil {
+		return err
+	}
+
+	return assertComparisons(value.Type(), config)
+}
+
+// assertBindable checks fields of given struct type. Types which are already
+// checked are skipped, so recursive types are supported.
+func assertBindable(
+	structType reflect.Type,
+	config *config,
+	prefix string,
+	checked map[reflect.Type]bool,
+) error {
+	if checked[structType] {
+		return nil
+	}
+
+	checked[structType] = true
+
+	structValue := reflect.New(structType).Elem()
+
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			field = structType.Field(i)
+			key   = config.getName(field, "from")
+			name  = prefix + key
+		)
+
+		if key == "" {
+			if config.strictNames && !isSkippedField(field) {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`field %s.%s has empty name`,
+						structType,
+						field.Name,
+					),
+				)
+			}
+
+			continue
+		}
+
+		if config.fieldFilter != nil && !config.fieldFilter(field) {
+			continue
+		}
+
+		// `required` tag is not used if RequiredTagKey option is changed.
+		value, ok := field.Tag.Lookup("required")
+		if ok && config.requiredTagKey == "required" {
+			if _, ok := parseRequiredTag(value); !ok {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`required tag of %s is not a bool: %q`,
+						name,
+						value,
+					),
+				)
+			}
+		}
+
+		if err := assertItemsLimits(field, name, config); err != nil {
+			return err
+		}
+
+		if err := assertOneOf(structValue, field, name); err != nil {
+			return err
+		}
+
+		_, hasTypeBinding := config.getTypeBinding(field)
+
+		if isInterfaceType(field) && !hasTypeBinding {
+			continue
+		}
+
+		if isPolymorphicType(field) {
+			if _, err := getDiscriminator(structType, field); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if !hasTypeBinding && (isNestedType(field) ||
+			isNestedSliceType(field) || isNestedMapType(field)) {
+			nestedType := field.Type
+			for nestedType.Kind() != reflect.Struct {
+				nestedType = nestedType.Elem()
+			}
+
+			err := assertBindable(nestedType, config, name+".", checked)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		bindingName, _ := parseBindingTag(field)
+		if _, ok := config.fieldBindings[bindingName]; ok {
+			continue
+		}
+
+		setter, err := getSetter(structValue, field)
+		if err != nil {
+			return err
+		}
+
+		bindingField := field
+		if setter.IsValid() {
+			bindingField.Type = getSetterType(field, setter)
+		}
+
+		if config.skipUnbindable && isUnbindable(bindingField, config) {
+			continue
+		}
+
+		binding, err := getFieldBinding(structValue, bindingField, config)
+		if err != nil {
+			return err
+		}
+
+		if _, err := getCoercionTable(field); err != nil {
+			return err
+		}
+
+		err = assertDefault(structType, bindingField, name, binding, config)
+		if err != nil {
+			return err
+		}
+
+		if err := assertBindingType(bindingField, name, binding); err != nil {
+			return err
+		}
+
+		for _, value := range strings.Fields(field.Tag.Get("oneof")) {
+			if _, err := binding(value); err != nil {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`oneof value %q of %s is invalid: %s`,
+						value,
+						name,
+						err,
+					),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// assertItemsLimits checks that `minitems` and `maxitems` tags are valid and
+// specified only for slice fields, which are the only ones where they are
+// enforced.
+func assertItemsLimits(
+	field reflect.StructField,
+	name string,
+	config *config,
+) error {
+	_, hasMin := field.Tag.Lookup("minitems")
+	_, hasMax := field.Tag.Lookup("maxitems")
+
+	if !hasMin && !hasMax {
+		return nil
+	}
+
+	if _, _, err := getItemsLimits(field); err != nil {
+		return err
+	}
+
+	bindingName, _ := parseBindingTag(field)
+
+	_, hasFieldBinding := config.fieldBindings[bindingName]
+	if isSliceType(field.Type) && !hasFieldBinding {
+		return nil
+	}
+
+	return InvalidBindingError(
+		fmt.Sprintf(
+			`minitems and maxitems tags of %s are supported only for slices`,
+			name,
+		),
+	)
+}
+
+// assertOneOf checks that `oneof` tag is specified only for fields which
+// values can be compared with listed values.
+func assertOneOf(
+	structValue reflect.Value,
+	field reflect.StructField,
+	name string,
+) error {
+	if _, ok := field.Tag.Lookup("oneof"); !ok {
+		return nil
+	}
+
+	valueType := field.Type
+
+	// Invalid setters are reported later.
+	if setter, err := getSetter(structValue, field); err == nil &&
+		setter.IsValid() {
+		valueType = getSetterType(field, setter)
+	}
+
+	if isOneOfType(valueType) {
+		return nil
+	}
+
+	return InvalidBindingError(
+		fmt.Sprintf(
+			`oneof tag of %s can't be used for values of type %s`,
+			name,
+			valueType,
+		),
+	)
+}
+
+// assertBindingType checks that value returned by binding can be set into
+// the field. Binding is called with string representation of zero value of
+// the field type, like `0`, and if it fails, type is not checked.
+func assertBindingType(
+	field reflect.StructField,
+	name string,
+	binding func(interface{}) (interface{}, error),
+) error {
+	if isMapType(field.Type) {
+		return nil
+	}
+
+	valueType := field.Type
+	if isSliceType(valueType) {
+		valueType = valueType.Elem()
+	}
+
+	value, err := binding(fmt.Sprint(
+		reflect.Zero(getBindingType(valueType)).Interface(),
+	))
+	if _, ok := err.(InvalidBindingError); ok {
+		return err
+	}
+
+	if err != nil {
+		return nil
+	}
+
+	if !setValue(reflect.New(valueType).Elem(), value) {
+		return InvalidBindingError(
+			fmt.Sprintf(
+				`binding of %s returns %T, which can't be set into %s`,
+				name,
+				value,
+				valueType,
+			),
+		)
+	}
+
+	return nil
+}
+
+// assertDefault checks that default value of the field can be bound or, if
+// default refers to other field, that referred field exists.
+func assertDefault(
+	structType reflect.Type,
+	field reflect.StructField,
+	name string,
+	binding func(interface{}) (interface{}, error),
+	config *config,
+) error {
+	value, ok := field.Tag.Lookup("default")
+	if !ok {
+		return nil
+	}
+
+	if reference, ok := getReference(field); ok {
+		source, ok := structType.FieldByName(reference)
+		if !ok || len(source.Index) != 1 || source.PkgPath != "" {
+			return InvalidBindingError(
+				fmt.Sprintf(
+					`default of %s refers to unknown field %q`,
+					name,
+					reference,
+				),
+			)
+		}
+
+		return nil
+	}
+
+	binder := &binder{config: config}
+
+	_, err := binder.bindData(
+		reflect.New(field.Type).Elem(),
+		field,
+		name,
+		value,
+		binding,
+	)
+	if err != nil {
+		return err
+	}
+
+	if len(binder.errors) > 0 {
+		return InvalidBindingError(
+			fmt.Sprintf(
+				`default value %q of %s is invalid: %s`,
+				value,
+				name,
+				binder.errors,
+			),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// atomicTypes lists types from sync/atomic which are bound using their Store
+// method.
+var atomicTypes = map[reflect.Type]bool{
+	reflect.TypeOf((*atomic.Int32)(nil)).Elem():  true,
+	reflect.TypeOf((*atomic.Int64)(nil)).Elem():  true,
+	reflect.TypeOf((*atomic.Uint32)(nil)).Elem(): true,
+	reflect.TypeOf((*atomic.Uint64)(nil)).Elem(): true,
+	reflect.TypeOf((*atomic.Bool)(nil)).Elem():   true,
+	reflect.TypeOf((*atomic.Value)(nil)).Elem():  true,
+}
+
+// isAtomicType reports whether field of given type (or pointer to it) should
+// be bound using Store method, like atomic.Int64.
+func isAtomicType(fieldType reflect.Type) bool {
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	return atomicTypes[fieldType]
+}
+
+// getAtomicStore returns Store method of atomic field, which is used as
+// field setter. Nil pointer fields are allocated when Store is called.
+func getAtomicStore(
+	structValue reflect.Value,
+	field reflect.StructField,
+) (reflect.Value, error) {
+	if field.PkgPath != "" || !structValue.CanAddr() {
+		return reflect.Value{}, InvalidBindingError(
+			fmt.Sprintf(
+				`atomic field %s.%s should be exported and addressable`,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	target := structValue.FieldByIndex(field.Index)
+	if target.Kind() != reflect.Ptr {
+		return target.Addr().MethodByName("Store"), nil
+	}
+
+	store := reflect.New(target.Type().Elem()).MethodByName("Store")
+
+	return reflect.MakeFunc(
+		store.Type(),
+		func(args []reflect.Value) []reflect.Value {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+
+			return target.MethodByName("Store").Call(args)
+		},
+	), nil
+}
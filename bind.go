@@ -1,21 +1,56 @@
-// Package binding offers easy way of binding form-like sources into structs.
-//
-// It's particularly useful with web-frameworks like gin.
-//
-// Package offers rich-structured errors which can be easily integrated into
-// UI error reports (like HTML page).
 package binding
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"net"
+	"net/mail"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 // FieldNameFunc represents function that retrieves field name by given
 // reflect type of field.
 type FieldNameFunc func(field reflect.StructField) string
 
+var defaultFieldNameFunc = struct {
+	sync.RWMutex
+	fn FieldNameFunc
+}{fn: getFieldName}
+
+// SetDefaultFieldNameFunc changes FieldNameFunc which will be used by every
+// Bind call that has no FieldNameFunc specified in options. Passing nil
+// restores built-in behavior. It's safe to call it concurrently with Bind.
+func SetDefaultFieldNameFunc(fn FieldNameFunc) {
+	defaultFieldNameFunc.Lock()
+	defer defaultFieldNameFunc.Unlock()
+
+	if fn == nil {
+		fn = getFieldName
+	}
+
+	defaultFieldNameFunc.fn = fn
+}
+
+func getDefaultFieldNameFunc() FieldNameFunc {
+	defaultFieldNameFunc.RLock()
+	defer defaultFieldNameFunc.RUnlock()
+
+	return defaultFieldNameFunc.fn
+}
+
+// Defaulter can be implemented by output struct to set default values for
+// it's fields. SetDefaults is called by Bind before binding, so fields which
+// have no mapped values will keep defaults.
+type Defaulter interface {
+	SetDefaults()
+}
+
 // MapFunc is a signature for function that maps field name into raw
 // representation. Only string return values are supported for now.
 type MapFunc func(name string) interface{}
@@ -26,69 +61,132 @@ type MapFunc func(name string) interface{}
 // by mapper function converting it's return value from string to appropriate
 // struct's field type.
 //
-// Additionally, struct's tags can be used to control binding. Following tags
-// will be inspected by Bind function: `binding`, `form` and `required`.
+// See package documentation for the list of supported tags, bindings and
+// options.
 //
-// Tag `binding` used to override binding function which will be used for
-// converting value returned by mapper function to struct's field type.
+// If output implements FieldSetter interface, it's fields are not
+// inspected: mapper is called for every name returned by FieldNames and
+// mapped values are passed to SetField as is. Errors returned by SetField
+// are reported as BindingError for the field.
 //
-// There are three built-in functions: `int`, `float`, `string`. They used to
-// parse mapped value into int, int8, int16, int32, int64, float32, float64 and
-// string types accordingly.
+// If output implements Defaulter interface, it's SetDefaults method will be
+// called before binding, so fields are first populated with defaults and
+// then overridden by mapped values.
 //
-// Binding `int` accepts two arguments in the form of `int:<bits>,<base>`,
-// which are optional and can be used to override automatically detected
-// bitness of resulting int and base of 10.
+// Mapper can return native bool and numeric values for non-slice fields, but
+// it's up to binding function to support them; built-in bindings except
+// `bool` support only strings.
 //
-// Binding `float` accepts one argument in the form of `float:<bits>`.
+// If binding function returns InvalidBindingError, which means that binding
+// is misconfigured, Bind stops and returns it as is. Other errors are
+// reported as BindingError for the field.
 //
-// Binding `string` has no arguments and do not apply any parsing to mapped
-// value.
-//
-// Tag `required` used to specify, that field should have mapped value and
-// error will be reported otherwise. Tag should be specified as
-// `required:"true"`.
-//
-// Tag `form` can be used to override field name that will be passed into
-// mapper function to obtain value. Bind will also inspect `json`, `bson`,
-// `yaml` and `toml` tags if `form` tag is not specified. If no known tags
-// specify mapped name, then field's name will be used.
-//
-// To customize binding behavior, third variable argument can be used:
+// Panics in bindings, including custom ones, are recovered and returned as
+// InvalidBindingError, so buggy binding can't crash the whole handler.
+func Bind(output interface{}, mapper MapFunc, options ...interface{}) error {
+	return bind(output, mapper, newConfig(options), nil)
+}
+
+// BindInto allocates value of type T, which should be a struct, binds
+// values provided by mapper into it like Bind does and returns it. If
+// BindingErrors are returned, value is returned as well with fields which
+// were bound successfully. Zero value is returned along with any other
+// error, like InvalidBindingError.
+func BindInto[T any](mapper MapFunc, options ...interface{}) (T, error) {
+	var output T
+
+	err := Bind(&output, mapper, options...)
+	if _, ok := err.(BindingErrors); err != nil && !ok {
+		var zero T
+
+		return zero, err
+	}
+
+	return output, err
+}
+
+// BindOne works like Bind with FailFast option, but returns only the first
+// binding error as is instead of BindingErrors. Note, that errors of other
+// fields are lost, so use Bind if all errors should be reported.
+func BindOne(output interface{}, mapper MapFunc, options ...interface{}) error {
+	config := newConfig(options)
+	config.failFast = true
+
+	err := bind(output, mapper, config, nil)
+	if errors, ok := err.(BindingErrors); ok {
+		return errors[0]
+	}
+
+	return err
+}
+
+// BindStrict works like Bind, but enables options which report misconfigured
+// structs, which is handy during development:
 //
-// To specify binding functions, pass functions in the form of
-// `Bindings{"<name>": <function>}`.
+//   - RequireBindableFields, so struct without bindable fields is reported;
+//   - RequireTaggedBindings, so fields with custom binding are required;
+//   - StrictNames, so fields with empty names are reported.
 //
-// To specify function that maps field to it's name, specify it as
-// `FieldNameFunc(<func>)`.
-func Bind(output interface{}, mapper MapFunc, options ...interface{}) error {
-	var bindings = Bindings{
-		"int":    bindInt,
-		"float":  bindFloat,
-		"string": bindString,
+// Options passed explicitly take precedence, e.g. `StrictNames(false)`
+// disables corresponding check.
+func BindStrict(
+	output interface{},
+	mapper MapFunc,
+	options ...interface{},
+) error {
+	strict := []interface{}{
+		RequireBindableFields(true),
+		RequireTaggedBindings(true),
+		StrictNames(true),
 	}
 
-	var fieldNameFunc = getFieldName
+	return Bind(output, mapper, append(strict, options...)...)
+}
 
-	for _, option := range options {
-		switch option := option.(type) {
-		case Bindings:
-			for key, binding := range option {
-				bindings[key] = binding
-			}
-		case FieldNameFunc:
-			fieldNameFunc = option
-		}
+func bind(
+	output interface{},
+	mapper MapFunc,
+	config *config,
+	report *BindReport,
+) error {
+	if setter, ok := output.(FieldSetter); ok {
+		return bindFieldSetter(setter, mapper, config)
 	}
 
 	if reflect.ValueOf(output).Kind() != reflect.Ptr {
 		return InvalidBindingError("specified output is not a pointer")
 	}
 
-	var (
-		structValue = reflect.Indirect(reflect.ValueOf(output))
-		structType  = structValue.Type()
+	return bindValue(
+		reflect.Indirect(reflect.ValueOf(output)),
+		mapper,
+		config,
+		report,
 	)
+}
+
+// BindValue works like Bind, but accepts reflect.Value of the output struct
+// instead of pointer to it. Value should be settable, e.g. obtained using
+// reflect.ValueOf(&output).Elem().
+func BindValue(
+	target reflect.Value,
+	mapper MapFunc,
+	options ...interface{},
+) error {
+	return bindValue(target, mapper, newConfig(options), nil)
+}
+
+func bindValue(
+	structValue reflect.Value,
+	mapper MapFunc,
+	config *config,
+	report *BindReport,
+) error {
+	if !structValue.IsValid() {
+		return InvalidBindingError("specified output is not a valid value")
+	}
+
+	structType := structValue.Type()
 
 	if structType.Kind() != reflect.Struct {
 		return InvalidBindingError(
@@ -103,61 +201,305 @@ func Bind(output interface{}, mapper MapFunc, options ...interface{}) error {
 		return InvalidBindingError(`output can not be set`)
 	}
 
-	var errors BindingErrors
+	if defaulter, ok := structValue.Addr().Interface().(Defaulter); ok {
+		defaulter.SetDefaults()
+	}
+
+	binder := &binder{
+		config: config,
+		report: report,
+	}
+
+	if len(config.comparisons) > 0 {
+		binder.values = map[string]interface{}{}
+	}
+
+	_, err := binder.bindStruct(structValue, config.wrapMapper(mapper), "")
+	if err != nil {
+		return err
+	}
+
+	binder.checkComparisons()
+
+	if config.requireBindableFields && binder.bindable == 0 {
+		return InvalidBindingError(
+			fmt.Sprintf(`%s has no fields which can be bound`, structType),
+		)
+	}
+
+	return binder.result()
+}
+
+// binder holds state of single Bind call.
+type binder struct {
+	config *config
+	report *BindReport
+	errors BindingErrors
+
+	// bindable is a number of exported fields which were eligible for
+	// binding.
+	bindable int
+
+	// truncated is set if binding was stopped because of MaxErrors limit.
+	truncated bool
+
+	// values holds bound values of fields keyed by names, which are used by
+	// comparisons. It's nil if there are no comparisons.
+	values map[string]interface{}
+}
+
+// result returns errors collected by binder, truncated according to
+// MaxErrors option, or nil if there are no errors.
+func (binder *binder) result() error {
+	limit := binder.config.maxErrors
+	if limit > 0 && len(binder.errors) > limit {
+		binder.errors = binder.errors[:limit]
+		binder.truncated = true
+	}
+
+	if binder.truncated {
+		binder.errors = append(binder.errors, TruncatedError{limit: limit})
+	}
+
+	if len(binder.errors) > 0 {
+		return binder.errors
+	}
+
+	return nil
+}
+
+// addError adds error for the field with given name. Errors which are not
+// bound to specific field are wrapped into BindingError, so they always can
+// be found using BindingErrors.Field.
+func (binder *binder) addError(name string, err error) {
+	if err, ok := err.(fieldError); ok && err.Name() != "" {
+		binder.errors = append(binder.errors, err)
+
+		return
+	}
+
+	binder.errors = append(binder.errors, BindingError{
+		name:  name,
+		cause: err,
+	})
+}
+
+// addErrors adds errors for the field with given name or it's elements.
+func (binder *binder) addErrors(name string, errors BindingErrors) {
+	for _, err := range errors {
+		binder.addError(name, err)
+	}
+}
+
+// isStopped reports whether binding should be stopped because of FailFast or
+// MaxErrors options.
+func (binder *binder) isStopped() bool {
+	if binder.config.failFast && len(binder.errors) > 0 {
+		return true
+	}
+
+	limit := binder.config.maxErrors
+	if limit > 0 && len(binder.errors) >= limit {
+		binder.truncated = true
+
+		return true
+	}
+
+	return false
+}
+
+// bindStruct binds fields of given struct value using mapper. Field names
+// are prefixed with prefix in errors and report. It returns true if at least
+// one field got mapped value.
+func (binder *binder) bindStruct(
+	structValue reflect.Value,
+	mapper MapFunc,
+	prefix string,
+) (bool, error) {
+	var (
+		structType  = structValue.Type()
+		config      = binder.config
+		bound       = false
+		references  []int
+		polymorphic []int
+	)
 
 	for i := 0; i < structType.NumField(); i++ {
+		if binder.isStopped() {
+			break
+		}
+
 		var (
 			field = structType.Field(i)
-			name  = fieldNameFunc(field)
+			key   = config.getName(field, "from")
+			name  = prefix + key
 		)
 
-		if name == "" {
+		if key == "" {
+			if config.strictNames && !isSkippedField(field) {
+				return false, InvalidBindingError(
+					fmt.Sprintf(
+						`field %s.%s has empty name`,
+						structType,
+						field.Name,
+					),
+				)
+			}
+
 			continue
 		}
 
-		if binding, ok := getBinding(field, bindings); !ok {
-			return InvalidBindingError(
-				fmt.Sprintf(
-					`binding for %s.%s is specified but not registered`,
-					structType,
-					field.Name,
-				),
+		if config.fieldFilter != nil && !config.fieldFilter(field) {
+			continue
+		}
+
+		if field.PkgPath == "" {
+			binder.bindable++
+		}
+
+		_, hasTypeBinding := config.getTypeBinding(field)
+
+		if isInterfaceType(field) && !hasTypeBinding {
+			continue
+		}
+
+		if isPolymorphicType(field) {
+			polymorphic = append(polymorphic, i)
+
+			continue
+		}
+
+		if isNestedType(field) && !hasTypeBinding {
+			nestedMapper, err := binder.getNestedMapper(mapper, key, name)
+			if err != nil {
+				return false, err
+			}
+
+			nestedBound, err := binder.bindNested(
+				structValue.Field(i),
+				nestedMapper,
+				name,
+				config.isRequired(field),
 			)
-		} else {
-			data := mapper(name)
+			if err != nil {
+				return false, err
+			}
 
-			if data == nil {
-				if isRequired(field) {
-					errors = append(errors, RequiredError{name: name})
-				}
+			bound = bound || nestedBound
 
-				continue
+			continue
+		}
+
+		if isNestedSliceType(field) && !hasTypeBinding {
+			nestedBound, err := binder.bindNestedSlice(
+				structValue.Field(i),
+				field,
+				mapper,
+				key,
+				name,
+			)
+			if err != nil {
+				return false, err
 			}
 
-			if _, ok := data.(string); !ok {
-				return InvalidBindingError(
-					fmt.Sprintf(
-						`binding values of type %T (%s.%s) is not supported`,
-						data,
-						structType,
-						field.Name,
-					),
-				)
+			bound = bound || nestedBound
+
+			continue
+		}
+
+		if isNestedMapType(field) && !hasTypeBinding {
+			nestedBound, err := binder.bindNestedMap(
+				structValue.Field(i),
+				mapper(key),
+				name,
+				config.isRequired(field),
+			)
+			if err != nil {
+				return false, err
 			}
 
-			value, err := binding(data.(string))
+			bound = bound || nestedBound
+
+			continue
+		}
+
+		bindingName, _ := parseBindingTag(field)
+
+		if binding, ok := config.fieldBindings[bindingName]; ok {
+			fieldBound, err := binder.bindField(
+				structValue,
+				i,
+				mapper,
+				key,
+				name,
+				recoverFieldBinding(binding),
+			)
 			if err != nil {
-				errors = append(errors, BindingError{
-					name:  name,
-					cause: err,
-				})
+				return false, err
+			}
+
+			bound = bound || fieldBound
+
+			continue
+		}
+
+		setter, err := getSetter(structValue, field)
+		if err != nil {
+			return false, err
+		}
+
+		bindingField := field
+		if setter.IsValid() {
+			bindingField.Type = getSetterType(field, setter)
+		}
+
+		if config.skipUnbindable && isUnbindable(bindingField, config) {
+			continue
+		}
+
+		binding, err := getFieldBinding(structValue, bindingField, config)
+		if err != nil {
+			return false, err
+		}
+
+		binding = recoverBinding(name, binding)
+
+		data := mapper(key)
+
+		if data == "" && config.emptyAsAbsent {
+			data = nil
+		}
+
+		direct := false
+
+		if data != nil {
+			bound = true
+		} else if value, ok := config.defaults[name]; ok {
+			data = value
+			direct = !isBindableDefault(value)
+		} else if value, ok := field.Tag.Lookup("default"); ok {
+			if _, ok := getReference(field); ok {
+				references = append(references, i)
 
 				continue
 			}
 
-			structField := structValue.Field(i)
-			if !structField.CanSet() {
-				return InvalidBindingError(
+			data = value
+		} else {
+			if config.isRequired(field) ||
+				config.requireTaggedBindings && hasCustomBinding(field) {
+				binder.addError(name, RequiredError{name: name})
+			}
+
+			continue
+		}
+
+		// Value is bound into temporary target, so it's stored only after
+		// it's validated.
+		target := reflect.New(bindingField.Type).Elem()
+		if !setter.IsValid() {
+			if !structValue.Field(i).CanSet() {
+				return false, InvalidBindingError(
 					fmt.Sprintf(
 						`field %s.%s is unexported and can not be set`,
 						structType.Name(),
@@ -166,20 +508,232 @@ func Bind(output interface{}, mapper MapFunc, options ...interface{}) error {
 				)
 			}
 
-			structField.Set(reflect.ValueOf(value))
+			target.Set(structValue.Field(i))
+		}
+
+		ok := true
+
+		if direct {
+			err = setDefaultValue(target, name, data)
+		} else {
+			ok, err = binder.bindData(target, bindingField, name, data, binding)
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			switch {
+			case setter.IsValid():
+			case config.zeroOnError:
+				structField := structValue.Field(i)
+				structField.Set(reflect.Zero(structField.Type()))
+			default:
+				// Maps keep entries which are bound successfully.
+				structValue.Field(i).Set(target)
+			}
+
+			continue
+		}
+
+		if config.skipZero && isEmpty(target) {
+			continue
+		}
+
+		if binder.report != nil {
+			binder.report.Values[name] = target.Interface()
+			binder.report.Provenance[name] = Provenance{
+				Name:     name,
+				RawValue: data,
+				Binding:  getBindingSpec(bindingField),
+			}
+
+			binder.report.Warnings = append(
+				binder.report.Warnings,
+				getWarnings(name, bindingField, data, config)...,
+			)
+		}
+
+		if err := validateField(bindingField, name, target); err != nil {
+			binder.addError(name, err)
+
+			if config.zeroOnError && !setter.IsValid() {
+				structField := structValue.Field(i)
+				structField.Set(reflect.Zero(structField.Type()))
+			}
+
+			continue
+		}
+
+		binder.capture(name, target)
+
+		if setter.IsValid() {
+			if err := callSetter(setter, target); err != nil {
+				binder.addError(name, err)
+			}
+		} else {
+			structValue.Field(i).Set(target)
+		}
+	}
+
+	if len(references) > 0 {
+		err := binder.bindReferences(structValue, references, prefix)
+		if err != nil {
+			return false, err
 		}
 	}
 
-	if len(errors) > 0 {
-		return errors
+	if len(polymorphic) > 0 {
+		err := binder.bindPolymorphic(structValue, polymorphic, mapper, prefix)
+		if err != nil {
+			return false, err
+		}
 	}
 
-	return nil
+	return bound, nil
+}
+
+// bindData binds mapped data into target using binding of the field. It
+// returns false if binding errors were reported.
+func (binder *binder) bindData(
+	target reflect.Value,
+	field reflect.StructField,
+	name string,
+	data interface{},
+	binding func(interface{}) (interface{}, error),
+) (bool, error) {
+	var (
+		config         = binder.config
+		bindingName, _ = parseBindingTag(field)
+	)
+
+	switch {
+	case isPassthroughMapType(field):
+		err := bindPassthroughMap(target, name, data)
+		if err != nil {
+			return false, err
+		}
+
+	case isMapType(field.Type):
+		mapErrors, err := bindMap(
+			target,
+			name,
+			data,
+			binding,
+			getSeparator(field, config.sliceSeparator),
+			bindingName == "header",
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if len(mapErrors) > 0 {
+			binder.addErrors(name, mapErrors)
+
+			return false, nil
+		}
+
+	case isSliceType(field.Type):
+		items, ok := getSliceItems(
+			data,
+			getSeparator(field, config.sliceSeparator),
+		)
+		if !ok {
+			return false, InvalidBindingError(
+				fmt.Sprintf(
+					`binding values of type %T (%s) is not supported`,
+					data,
+					name,
+				),
+			)
+		}
+
+		minItems, maxItems, err := getItemsLimits(field)
+		if err != nil {
+			return false, err
+		}
+
+		if len(items) < minItems || maxItems > 0 && len(items) > maxItems {
+			binder.addError(name, LengthError{
+				name:   name,
+				actual: len(items),
+				min:    minItems,
+				max:    maxItems,
+			})
+
+			return false, nil
+		}
+
+		sliceErrors, err := bindSlice(
+			target,
+			name,
+			items,
+			binding,
+			config.reuseSlices,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if len(sliceErrors) > 0 {
+			binder.addErrors(name, sliceErrors)
+
+			return false, nil
+		}
+
+	default:
+		if !isSupportedValue(data) {
+			return false, InvalidBindingError(
+				fmt.Sprintf(
+					`binding values of type %T (%s) is not supported`,
+					data,
+					name,
+				),
+			)
+		}
+
+		value, err := binding(data)
+		if err, ok := err.(InvalidBindingError); ok {
+			return false, err
+		}
+
+		if err != nil {
+			binder.addError(name, err)
+
+			return false, nil
+		}
+
+		if !setValue(target, value) {
+			return false, InvalidBindingError(
+				fmt.Sprintf(
+					`binding of %s returned %T, which can't be set`,
+					name,
+					value,
+				),
+			)
+		}
+	}
+
+	return true, nil
 }
 
+// fieldNameTags lists tags which can specify field name, in order of
+// precedence.
+var fieldNameTags = []string{"form", "json", "bson", "yaml", "toml", "xml"}
+
+// getFieldName returns name of the field specified by first of known tags
+// or field's name itself. Every known tag uses comma to separate name from
+// options, like `json:"id,omitempty"`, `yaml:"items,flow"` or
+// `toml:"port,omitzero"`, so options are stripped. Tags with empty name, like
+// `yaml:",inline"`, don't specify name, so next tag is inspected.
 func getFieldName(field reflect.StructField) string {
-	for _, key := range []string{"form", "json", "bson", "yaml", "toml"} {
+	for _, key := range fieldNameTags {
 		if name, ok := field.Tag.Lookup(key); ok {
+			if name == "-" {
+				return ""
+			}
+
 			name = strings.Split(name, ",")[0]
 			if name != "" {
 				return name
@@ -190,43 +744,329 @@ func getFieldName(field reflect.StructField) string {
 	return field.Name
 }
 
-func isRequired(field reflect.StructField) bool {
-	value, ok := field.Tag.Lookup("required")
+// isInterfaceType reports whether field is of interface type, like
+// interface{} or error, and has no `binding` tag. Such fields have no default
+// binding, so they are skipped.
+func isInterfaceType(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Interface &&
+		field.Tag.Get("binding") == ""
+}
+
+// isSkippedField reports whether field is explicitly skipped using `-`
+// name, like `form:"-"` or `from:"-"`.
+func isSkippedField(field reflect.StructField) bool {
+	if field.Tag.Get("from") == "-" {
+		return true
+	}
+
+	for _, key := range fieldNameTags {
+		if name, ok := field.Tag.Lookup(key); ok && name == "-" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSupportedValue reports whether mapped value of that type can be passed to
+// binding function of non-slice field. Besides strings, native bool and
+// numeric values are supported, like ones produced by decoding JSON.
+func isSupportedValue(data interface{}) bool {
+	switch data.(type) {
+	case string, []string:
+		return true
+	}
+
+	switch reflect.ValueOf(data).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasCustomBinding reports whether field has `binding` tag which specifies
+// binding other than default one for the field type.
+func hasCustomBinding(field reflect.StructField) bool {
+	tag, _ := field.Tag.Lookup("binding")
+	if tag == "" {
+		return false
+	}
+
+	name, _ := parseBindingTag(field)
+	defaultName, _ := parseBindingTag(reflect.StructField{Type: field.Type})
+
+	return name != defaultName
+}
+
+// isRequired reports whether field is marked as required by tag with given
+// key. Tag value should be either true bool, like `required:"true"`, or
+// comma-separated list containing `required`, like `validate:"required"`.
+func isRequired(field reflect.StructField, key string) bool {
+	value, ok := field.Tag.Lookup(key)
+	if !ok {
+		return false
+	}
+
+	required, _ := parseRequiredTag(value)
 
-	return ok && value == "true"
+	return required
+}
+
+// parseRequiredTag parses value of tag which marks field as required. It
+// returns false as second value if tag is neither bool nor comma-separated
+// list containing `required`.
+func parseRequiredTag(value string) (bool, bool) {
+	if required, err := strconv.ParseBool(value); err == nil {
+		return required, true
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "required" {
+			return true, true
+		}
+	}
+
+	return false, false
 }
 
 func getBinding(
 	field reflect.StructField,
 	bindings map[string]BindFunc,
-) (func(string) (interface{}, error), bool) {
+) (func(interface{}) (interface{}, error), bool) {
+	if tag := field.Tag.Get("binding"); strings.Contains(tag, "||") {
+		return getFallbackBinding(field, tag, bindings)
+	}
+
+	name, opts := parseBindingTag(field)
+	opts, _, _ = splitSeparatorOption(opts)
+
+	if binding, ok := bindings[name]; ok {
+		return preprocess(field, func(data interface{}) (interface{}, error) {
+			return binding(data, opts)
+		}), true
+	}
+
+	return nil, false
+}
+
+// getFallbackBinding returns binding which tries bindings separated by `||`
+// in `binding` tag one by one until one succeeds, like `int||string`. Error
+// of the last binding is returned if every binding fails.
+func getFallbackBinding(
+	field reflect.StructField,
+	tag string,
+	bindings map[string]BindFunc,
+) (func(interface{}) (interface{}, error), bool) {
+	tag, _, _ = splitSeparatorOption(tag)
+
+	var alternatives []func(interface{}) (interface{}, error)
+
+	for _, alternative := range strings.Split(tag, "||") {
+		binding, ok := getBinding(
+			reflect.StructField{
+				Type: field.Type,
+				Tag: reflect.StructTag(
+					`binding:` + strconv.Quote(alternative),
+				),
+			},
+			bindings,
+		)
+		if !ok {
+			return nil, false
+		}
+
+		alternatives = append(alternatives, binding)
+	}
+
+	return preprocess(field, func(data interface{}) (interface{}, error) {
+		var err error
+
+		for _, binding := range alternatives {
+			var value interface{}
+
+			value, err = binding(data)
+			if _, ok := err.(InvalidBindingError); ok {
+				return nil, err
+			}
+
+			if err == nil {
+				return value, nil
+			}
+		}
+
+		return nil, err
+	}), true
+}
+
+// isUnbindable reports whether field has no binding at all: it has no
+// `binding` tag, no type binding and there is no registered default binding
+// for it's type.
+func isUnbindable(field reflect.StructField, config *config) bool {
+	if field.Tag.Get("binding") != "" || isPassthroughMapType(field) {
+		return false
+	}
+
+	if _, ok := config.getTypeBinding(field); ok {
+		return false
+	}
+
+	_, ok := getBinding(field, config.bindings)
+
+	return !ok
+}
+
+// getFieldBinding returns binding for the field of given struct, which is
+// either registered binding or method of the struct, like `@ParseSlug`.
+func getFieldBinding(
+	structValue reflect.Value,
+	field reflect.StructField,
+	config *config,
+) (func(interface{}) (interface{}, error), error) {
+	if binding, ok := config.getTypeBinding(field); ok {
+		return preprocess(field, func(data interface{}) (interface{}, error) {
+			return binding(data, "")
+		}), nil
+	}
+
+	if isPassthroughMapType(field) {
+		return func(data interface{}) (interface{}, error) {
+			return data, nil
+		}, nil
+	}
+
+	if name, _ := parseBindingTag(field); strings.HasPrefix(name, "@") {
+		binding, err := getMethodBinding(structValue, field, name[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		return preprocess(field, binding), nil
+	}
+
+	binding, ok := getBinding(field, config.bindings)
+	if !ok && config.ignoreUnknownBindings {
+		binding, ok = getBinding(
+			reflect.StructField{Type: field.Type},
+			config.bindings,
+		)
+		if !ok {
+			binding = func(data interface{}) (interface{}, error) {
+				return bindString(data, "")
+			}
+		}
+
+		return preprocess(field, binding), nil
+	}
+
+	name, _ := parseBindingTag(field)
+
+	if !ok && name == "" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding for %s.%s is not specified and type %s has `+
+					`no default binding, specify it using binding tag `+
+					`or TypeBindings option`,
+				structValue.Type(),
+				field.Name,
+				getBindingType(field.Type),
+			),
+		)
+	}
+
+	if _, extended := ExtendedBindings()[name]; !ok && extended {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding %s for %s.%s is not registered, `+
+					`it's provided by ExtendedBindings option`,
+				name,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding for %s.%s is specified but not registered`,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	return binding, nil
+}
+
+// parseBindingTag splits `binding` tag of the field into binding name and
+// options string. Default binding for the field type is used if tag is not
+// specified.
+func parseBindingTag(field reflect.StructField) (string, string) {
 	tag, _ := field.Tag.Lookup("binding")
 	if tag == "" {
-		tag = getDefaultBindingTag(field)
+		tag = getDefaultBindingTag(getBindingType(field.Type))
 	}
 
-	var (
-		args = strings.SplitN(tag, ":", 2)
-		name = args[0]
-		opts = ""
-	)
+	// Structs with exported fields are bound as nested unless binding is
+	// specified explicitly.
+	if tag == "" && !hasExportedFields(getBindingType(field.Type)) &&
+		isUnmarshalerType(field.Type) {
+		tag = "unmarshal"
+	}
 
-	if len(args) == 2 {
-		opts = args[1]
+	end := strings.IndexAny(tag, ":;")
+	if end < 0 {
+		return tag, ""
 	}
 
-	if binding, ok := bindings[name]; ok {
-		return func(data string) (interface{}, error) {
-			return binding(data, opts)
-		}, true
-	} else {
-		return nil, false
+	return tag[:end], tag[end+1:]
+}
+
+// hasExportedFields reports whether given type is a struct with at least one
+// exported field.
+func hasExportedFields(structType reflect.Type) bool {
+	if structType.Kind() != reflect.Struct {
+		return false
+	}
+
+	for i := 0; i < structType.NumField(); i++ {
+		if structType.Field(i).PkgPath == "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getBindingSpec returns binding name with options which is used for the
+// field, like `int:8`.
+func getBindingSpec(field reflect.StructField) string {
+	name, opts := parseBindingTag(field)
+	if opts == "" {
+		return name
 	}
 
-	return nil, true
+	return name + ":" + opts
 }
 
-func getDefaultBindingTag(field reflect.StructField) string {
+func getDefaultBindingTag(fieldType reflect.Type) string {
+	var types = map[reflect.Type]string{
+		reflect.TypeOf(time.Duration(0)): "duration",
+		reflect.TypeOf(time.Time{}):      "time",
+		reflect.TypeOf(url.URL{}):        "url",
+		reflect.TypeOf(net.IP{}):         "ip",
+		reflect.TypeOf(big.Rat{}):        "rat",
+		reflect.TypeOf(mail.Address{}):   "email",
+	}
+
+	if tag, ok := types[fieldType]; ok {
+		return tag
+	}
+
 	var defaults = map[reflect.Kind]string{
 		reflect.Int:   "int",
 		reflect.Int8:  "int:8",
@@ -234,11 +1074,136 @@ func getDefaultBindingTag(field reflect.StructField) string {
 		reflect.Int32: "int:32",
 		reflect.Int64: "int:64",
 
+		reflect.Uint:   "uint",
+		reflect.Uint8:  "uint:8",
+		reflect.Uint16: "uint:16",
+		reflect.Uint32: "uint:32",
+		reflect.Uint64: "uint:64",
+
 		reflect.Float32: "float:32",
 		reflect.Float64: "float:64",
 
+		reflect.Complex64:  "complex:64",
+		reflect.Complex128: "complex:128",
+
 		reflect.String: "string",
+
+		reflect.Bool: "bool",
+	}
+
+	return defaults[fieldType.Kind()]
+}
+
+// getBindingType returns type of value which binding function should
+// produce for the field of given type, stripping pointers and slices.
+func getBindingType(fieldType reflect.Type) reflect.Type {
+	if isMapType(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+
+	if isSliceType(fieldType) {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	return fieldType
+}
+
+// setValue sets value returned by binding function into target. Values of
+// named types with same underlying kind are converted, as well as builtin
+// integers into integers of other kinds if they fit and byte slices into
+// byte arrays of same length. Pointer targets are allocated, while
+// non-nil pointers are dereferenced for non-pointer targets, like *big.Rat
+// returned by `rat` binding for big.Rat fields.
+func setValue(target reflect.Value, value interface{}) bool {
+	source := reflect.ValueOf(value)
+	if !source.IsValid() {
+		target.Set(reflect.Zero(target.Type()))
+
+		return true
+	}
+
+	var (
+		sourceType = source.Type()
+		targetType = target.Type()
+	)
+
+	switch {
+	case sourceType.AssignableTo(targetType):
+		target.Set(source)
+
+	case sourceType.Kind() == reflect.Ptr && !source.IsNil() &&
+		sourceType.Elem().AssignableTo(targetType):
+		target.Set(source.Elem())
+
+	case targetType.Kind() == reflect.Ptr:
+		pointer := reflect.New(targetType.Elem())
+		if !setValue(pointer.Elem(), value) {
+			return false
+		}
+
+		target.Set(pointer)
+
+	case sourceType.Kind() == targetType.Kind() &&
+		sourceType.ConvertibleTo(targetType):
+		target.Set(source.Convert(targetType))
+
+	case sourceType.Name() == sourceType.Kind().String() &&
+		(isKind(source, intKinds) || isKind(source, uintKinds)):
+		return setInteger(target, source)
+
+	case sourceType.Kind() == reflect.Slice &&
+		targetType.Kind() == reflect.Array &&
+		source.Len() == target.Len() &&
+		sourceType.ConvertibleTo(targetType):
+		target.Set(source.Convert(targetType))
+
+	default:
+		return false
+	}
+
+	return true
+}
+
+// setInteger sets integer source into target of other integer kind if it
+// fits into target.
+func setInteger(target reflect.Value, source reflect.Value) bool {
+	switch {
+	case isKind(target, intKinds) && isKind(source, intKinds):
+		if target.OverflowInt(source.Int()) {
+			return false
+		}
+
+		target.SetInt(source.Int())
+
+	case isKind(target, intKinds):
+		if source.Uint() > math.MaxInt64 ||
+			target.OverflowInt(int64(source.Uint())) {
+			return false
+		}
+
+		target.SetInt(int64(source.Uint()))
+
+	case isKind(target, uintKinds) && isKind(source, uintKinds):
+		if target.OverflowUint(source.Uint()) {
+			return false
+		}
+
+		target.SetUint(source.Uint())
+
+	case isKind(target, uintKinds):
+		if source.Int() < 0 || target.OverflowUint(uint64(source.Int())) {
+			return false
+		}
+
+		target.SetUint(uint64(source.Int()))
+
+	default:
+		return false
 	}
 
-	return defaults[field.Type.Kind()]
+	return true
 }
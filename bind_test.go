@@ -1,9 +1,24 @@
 package binding
 
 import (
+	"container/list"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
+	"html/template"
 	"math"
+	"math/big"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/netip"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -184,3 +199,5210 @@ func TestBin_CanUseCustomFieldNameFunc(t *testing.T) {
 	test.Empty(user.Name)
 	test.Equal(27, user.Age)
 }
+
+func TestBind_CanValidateOneOf(t *testing.T) {
+	test := assert.New(t)
+
+	var car struct {
+		Color  string `oneof:"red green blue"`
+		Wheels int    `oneof:"3 4"`
+		Doors  int    `oneof:"2 4"`
+	}
+
+	err := Bind(&car, func(key string) interface{} {
+		switch key {
+		case "Color":
+			return "black"
+		case "Wheels":
+			return "4"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{OneOfError{"Color", []string{"red", "green", "blue"}}},
+		err,
+	)
+	test.NotNil(err.(BindingErrors).Field("Color"))
+	test.Nil(err.(BindingErrors).Field("Wheels"))
+	test.Nil(err.(BindingErrors).Field("Doors"))
+	test.Equal(4, car.Wheels)
+	test.Empty(car.Color)
+}
+
+func TestBind_CanValidateOneOfPointersAndSlices(t *testing.T) {
+	test := assert.New(t)
+
+	var car struct {
+		Color   *string  `oneof:"red green"`
+		Options []string `oneof:"radio ac"`
+		Extras  []string `oneof:"radio ac"`
+	}
+
+	extras := []string{"radio"}
+	car.Extras = extras
+
+	err := Bind(&car, func(key string) interface{} {
+		switch key {
+		case "Color":
+			return "red"
+		case "Options":
+			return "radio,ac"
+		default:
+			return "radio,tv"
+		}
+	})
+
+	test.Equal(
+		BindingErrors{OneOfError{"Extras", []string{"radio", "ac"}}},
+		err,
+	)
+	test.Equal("red", *car.Color)
+	test.Equal([]string{"radio", "ac"}, car.Options)
+	test.Equal(extras, car.Extras)
+}
+
+func TestBind_CanUseDefaultFieldNameFunc(t *testing.T) {
+	test := assert.New(t)
+
+	SetDefaultFieldNameFunc(func(field reflect.StructField) string {
+		return strings.ToLower(field.Name)
+	})
+	defer SetDefaultFieldNameFunc(nil)
+
+	var user struct {
+		Name string
+		Age  int
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "name":
+			return "John Doe"
+		case "age", "Age":
+			return "27"
+		default:
+			return nil
+		}
+	}
+
+	err := Bind(&user, mapper)
+
+	test.NoError(err)
+	test.Equal("John Doe", user.Name)
+	test.Equal(27, user.Age)
+
+	user.Name = ""
+
+	fieldName := FieldNameFunc(func(field reflect.StructField) string {
+		return field.Name
+	})
+
+	err = Bind(&user, mapper, fieldName)
+
+	test.NoError(err)
+	test.Empty(user.Name)
+}
+
+func TestBind_CanBindSlices(t *testing.T) {
+	test := assert.New(t)
+
+	var post struct {
+		Tags    []string
+		Ratings []int
+		Scores  []float64
+	}
+
+	err := Bind(&post, func(key string) interface{} {
+		switch key {
+		case "Tags":
+			return []string{"go", "binding"}
+		case "Ratings":
+			return "1,2,3"
+		case "Scores":
+			return "1.5,x,2.5,y"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{
+			BindingError{"Scores[1]", SyntaxError{&strconv.NumError{
+				Func: "ParseFloat",
+				Num:  "x",
+				Err:  strconv.ErrSyntax,
+			}}},
+			BindingError{"Scores[3]", SyntaxError{&strconv.NumError{
+				Func: "ParseFloat",
+				Num:  "y",
+				Err:  strconv.ErrSyntax,
+			}}},
+		},
+		err,
+	)
+	test.Equal([]string{"go", "binding"}, post.Tags)
+	test.Equal([]int{1, 2, 3}, post.Ratings)
+	test.Nil(post.Scores)
+}
+
+func TestBind_CanReuseSlices(t *testing.T) {
+	test := assert.New(t)
+
+	var post struct {
+		Ratings []int
+	}
+
+	ratings := make([]int, 1, 4)
+	post.Ratings = ratings
+
+	mapper := func(key string) interface{} {
+		return "1,2,3"
+	}
+
+	err := Bind(&post, mapper, ReuseSlices(true))
+
+	test.NoError(err)
+	test.Equal([]int{1, 2, 3}, post.Ratings)
+	test.Equal(1, ratings[0])
+	test.Same(&ratings[0], &post.Ratings[0])
+
+	err = Bind(&post, mapper)
+
+	test.NoError(err)
+	test.Equal([]int{1, 2, 3}, post.Ratings)
+	test.NotSame(&ratings[0], &post.Ratings[0])
+}
+
+func benchmarkBindSlices(b *testing.B, options ...interface{}) {
+	var post struct {
+		Ratings []int
+		Tags    []string
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Ratings":
+			return []string{"1", "2", "3", "4", "5", "6", "7", "8"}
+		case "Tags":
+			return []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+		default:
+			return nil
+		}
+	}
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := Bind(&post, mapper, options...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkBind_Slices(b *testing.B) {
+	benchmarkBindSlices(b)
+}
+
+func BenchmarkBind_ReuseSlices(b *testing.B) {
+	benchmarkBindSlices(b, ReuseSlices(true))
+}
+
+func TestBind_DistinguishesSyntaxAndRangeErrors(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Age    int8
+		Height float32
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Age":
+			return "1000"
+		case "Height":
+			return "tall"
+		default:
+			return nil
+		}
+	})
+
+	test.Error(err)
+
+	var (
+		syntaxError SyntaxError
+		rangeError  RangeError
+	)
+
+	test.True(errors.As(err.(BindingErrors).Field("Age"), &rangeError))
+	test.False(errors.As(err.(BindingErrors).Field("Age"), &syntaxError))
+	test.Equal("1000", rangeError.Value())
+
+	test.True(errors.As(err.(BindingErrors).Field("Height"), &syntaxError))
+	test.False(errors.As(err.(BindingErrors).Field("Height"), &rangeError))
+	test.Equal("tall", syntaxError.Value())
+
+	test.True(errors.Is(err.(BindingErrors).Field("Age"), strconv.ErrRange))
+}
+
+func TestBind_CanBindUUIDBytes(t *testing.T) {
+	test := assert.New(t)
+
+	var record struct {
+		ID       [16]byte `binding:"uuidbytes"`
+		ParentID []byte   `binding:"uuidbytes"`
+		OwnerID  []byte   `binding:"uuidbytes"`
+	}
+
+	err := Bind(&record, func(key string) interface{} {
+		switch key {
+		case "OwnerID":
+			return "123e4567-e89b-12d3-a456-42661417400"
+		default:
+			return "123e4567-e89b-12d3-a456-426614174000"
+		}
+	})
+
+	expected := []byte{
+		0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3,
+		0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00,
+	}
+
+	test.Error(err)
+	test.NotNil(err.(BindingErrors).Field("OwnerID"))
+	test.Len(err.(BindingErrors), 1)
+	test.Equal(expected, record.ID[:])
+	test.Equal(expected, record.ParentID)
+	test.Nil(record.OwnerID)
+}
+
+func TestBind_CanBindTriStateBoolPointers(t *testing.T) {
+	test := assert.New(t)
+
+	var patch struct {
+		Active   *bool
+		Verified *bool
+		Banned   *bool
+		Admin    *bool
+	}
+
+	err := Bind(&patch, func(key string) interface{} {
+		switch key {
+		case "Active":
+			return "true"
+		case "Verified":
+			return "false"
+		case "Admin":
+			return "maybe"
+		default:
+			return nil
+		}
+	})
+
+	test.Error(err)
+	test.NotNil(err.(BindingErrors).Field("Admin"))
+
+	if test.NotNil(patch.Active) {
+		test.True(*patch.Active)
+	}
+
+	if test.NotNil(patch.Verified) {
+		test.False(*patch.Verified)
+	}
+
+	test.Nil(patch.Banned)
+	test.Nil(patch.Admin)
+}
+
+func TestBindWithReport_ReturnsBoundValues(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name   string
+		Age    int `form:"age"`
+		Height int
+		Weight int
+	}
+
+	report, err := BindWithReport(&user, func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "John Doe"
+		case "age":
+			return "27"
+		case "Weight":
+			return "heavy"
+		default:
+			return nil
+		}
+	})
+
+	test.Error(err)
+	test.Equal(
+		map[string]interface{}{"Name": "John Doe", "age": 27},
+		report.Values,
+	)
+}
+
+func TestBind_CanUseSliceSeparator(t *testing.T) {
+	test := assert.New(t)
+
+	var post struct {
+		Tags     []string
+		Ratings  []int8 `binding:"int:8;sep=|"`
+		Comments []string
+		Scores   []int `binding:"int;sep=;"`
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Tags":
+			return "a;b"
+		case "Ratings":
+			return "1|2"
+		case "Comments":
+			return "x,y"
+		case "Scores":
+			return "3;4"
+		default:
+			return nil
+		}
+	}
+
+	err := Bind(&post, mapper)
+
+	test.NoError(err)
+	test.Equal([]string{"a;b"}, post.Tags)
+	test.Equal([]int8{1, 2}, post.Ratings)
+	test.Equal([]string{"x", "y"}, post.Comments)
+	test.Equal([]int{3, 4}, post.Scores)
+
+	err = Bind(&post, mapper, SliceSeparator(";"))
+
+	test.NoError(err)
+	test.Equal([]string{"a", "b"}, post.Tags)
+	test.Equal([]int8{1, 2}, post.Ratings)
+	test.Equal([]string{"x,y"}, post.Comments)
+	test.Equal([]int{3, 4}, post.Scores)
+}
+
+func TestBind_CanBindMaps(t *testing.T) {
+	test := assert.New(t)
+
+	var search struct {
+		Filters map[string][]string
+		Limits  map[string]int
+		Boosts  map[string]float64
+	}
+
+	err := Bind(&search, func(key string) interface{} {
+		switch key {
+		case "Filters":
+			return url.Values{
+				"color": {"red", "green"},
+				"size":  {"xl"},
+			}
+		case "Limits":
+			return map[string]string{"tags": "10", "pages": "x"}
+		case "Boosts":
+			return map[string]interface{}{"title": "1.5"}
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{
+			BindingError{"Limits[pages]", SyntaxError{&strconv.NumError{
+				Func: "ParseInt",
+				Num:  "x",
+				Err:  strconv.ErrSyntax,
+			}}},
+		},
+		err,
+	)
+	test.Equal(
+		map[string][]string{"color": {"red", "green"}, "size": {"xl"}},
+		search.Filters,
+	)
+	test.Equal(map[string]int{"tags": 10}, search.Limits)
+	test.Equal(map[string]float64{"title": 1.5}, search.Boosts)
+}
+
+func TestBind_CanRequireTaggedBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var contract struct {
+		ExpiresIn time.Duration `binding:"interval"`
+		Age       int           `binding:"int"`
+		Name      string
+	}
+
+	var bindDuration = func(data interface{}, _ string) (interface{}, error) {
+		return time.ParseDuration(data.(string))
+	}
+
+	mapper := func(key string) interface{} {
+		return nil
+	}
+
+	err := Bind(&contract, mapper, Bindings{"interval": bindDuration})
+
+	test.NoError(err)
+
+	err = Bind(
+		&contract,
+		mapper,
+		Bindings{"interval": bindDuration},
+		RequireTaggedBindings(true),
+	)
+
+	test.Equal(BindingErrors{RequiredError{"ExpiresIn"}}, err)
+}
+
+func TestUnbind_CanOmitEmptyFields(t *testing.T) {
+	test := assert.New(t)
+
+	var height = 0
+
+	var user = struct {
+		Name    string `form:"name,omitempty"`
+		Age     int    `json:"age,omitempty"`
+		Email   string `json:"email"`
+		Tags    []string
+		Ratings []int  `json:",omitempty"`
+		Height  *int   `json:"height,omitempty"`
+		Weight  *int   `json:"weight,omitempty"`
+		Nick    string `form:",omitempty" json:"nick"`
+	}{
+		Name:   "",
+		Age:    27,
+		Tags:   []string{"a", "b"},
+		Height: &height,
+	}
+
+	values, err := Unbind(&user)
+
+	test.NoError(err)
+	test.Equal(
+		map[string]string{
+			"age":    "27",
+			"email":  "",
+			"Tags":   "a,b",
+			"height": "0",
+		},
+		values,
+	)
+}
+
+func TestUnbind_ReportsNonStructInput(t *testing.T) {
+	test := assert.New(t)
+
+	var user *struct {
+		Name string
+	}
+
+	for _, input := range []interface{}{nil, user, "string"} {
+		values, err := Unbind(input)
+
+		test.Nil(values)
+		test.IsType(InvalidBindingError(""), err, "%T", input)
+	}
+}
+
+func TestBind_CanFailFast(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name string `required:"true"`
+		Age  int    `required:"true"`
+	}
+
+	mapper := func(key string) interface{} {
+		return nil
+	}
+
+	err := Bind(&user, mapper, FailFast(true))
+
+	test.Equal(BindingErrors{RequiredError{"Name"}}, err)
+}
+
+func TestBindOne_ReturnsFirstError(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name string `required:"true"`
+		Age  int
+	}
+
+	err := BindOne(&user, func(key string) interface{} {
+		if key == "Age" {
+			return "old"
+		}
+
+		return nil
+	})
+
+	test.Equal(RequiredError{"Name"}, err)
+
+	err = BindOne(&user, func(key string) interface{} {
+		return "27"
+	})
+
+	test.NoError(err)
+	test.Equal(27, user.Age)
+
+	err = BindOne(user, func(key string) interface{} {
+		return nil
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+type testSettings struct {
+	Limit  int
+	Offset int
+}
+
+func (settings *testSettings) SetDefaults() {
+	settings.Limit = 10
+	settings.Offset = 0
+}
+
+func TestBind_CallsSetDefaults(t *testing.T) {
+	test := assert.New(t)
+
+	settings := testSettings{Offset: 5}
+
+	err := Bind(&settings, func(key string) interface{} {
+		if key == "Offset" {
+			return "20"
+		}
+
+		return nil
+	})
+
+	test.NoError(err)
+	test.Equal(10, settings.Limit)
+	test.Equal(20, settings.Offset)
+}
+
+func TestBind_CanBindNestedStructs(t *testing.T) {
+	test := assert.New(t)
+
+	type address struct {
+		City string
+		Zip  int
+	}
+
+	var user struct {
+		Name    string
+		Address address
+		Billing *address
+		Office  *address
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "John Doe"
+		case "Address.City":
+			return "NYC"
+		case "Address.Zip":
+			return "zip"
+		case "Billing.Zip":
+			return "10001"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{
+			BindingError{"Address.Zip", SyntaxError{&strconv.NumError{
+				Func: "ParseInt",
+				Num:  "zip",
+				Err:  strconv.ErrSyntax,
+			}}},
+		},
+		err,
+	)
+	test.Equal("John Doe", user.Name)
+	test.Equal("NYC", user.Address.City)
+	test.Equal(&address{Zip: 10001}, user.Billing)
+	test.Nil(user.Office)
+}
+
+func TestBind_CanBindNestedMaps(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Address struct {
+			City string
+			Zip  int `required:"true"`
+		}
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Address":
+			return map[string]interface{}{"City": "NYC"}
+		default:
+			return nil
+		}
+	}
+
+	err := Bind(&user, mapper, NestedMaps(true))
+
+	test.Equal(BindingErrors{RequiredError{"Address.Zip"}}, err)
+	test.Equal("NYC", user.Address.City)
+
+	err = Bind(&user, func(key string) interface{} {
+		return "NYC"
+	}, NestedMaps(true))
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBindingErrors_Accessors(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name   string `required:"true"`
+		Age    int
+		Height int
+		Tags   []int
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Age":
+			return "old"
+		case "Height":
+			return "180"
+		case "Tags":
+			return "1,x,y"
+		default:
+			return nil
+		}
+	})
+
+	errs := err.(BindingErrors)
+
+	test.Equal(4, errs.Len())
+	test.True(errs.HasField("Name"))
+	test.True(errs.HasField("Age"))
+	test.False(errs.HasField("Height"))
+	test.Equal([]string{"Name", "Age", "Tags[1]", "Tags[2]"}, errs.Fields())
+	test.Equal(
+		BindingErrors{RequiredError{"Name"}},
+		errs.Filter(RequiredError{}),
+	)
+	test.Len(errs.Filter(BindingError{}), 3)
+	test.Empty(errs.Filter(OneOfError{}))
+}
+
+func TestBind_CanBindDurations(t *testing.T) {
+	test := assert.New(t)
+
+	var timeouts struct {
+		Read    time.Duration
+		Write   time.Duration `binding:"duration:unit=s"`
+		Idle    time.Duration `binding:"duration:unit=ms"`
+		Connect time.Duration
+	}
+
+	err := Bind(&timeouts, func(key string) interface{} {
+		switch key {
+		case "Read":
+			return "1m30s"
+		case "Write":
+			return "5"
+		case "Idle":
+			return "2s"
+		case "Connect":
+			return "5"
+		default:
+			return nil
+		}
+	})
+
+	test.Len(err, 1)
+	test.NotNil(err.(BindingErrors).Field("Connect"))
+	test.Equal(90*time.Second, timeouts.Read)
+	test.Equal(5*time.Second, timeouts.Write)
+	test.Equal(2*time.Second, timeouts.Idle)
+
+	var invalid struct {
+		Timeout time.Duration `binding:"duration:unit=days"`
+	}
+
+	err = Bind(&invalid, func(key string) interface{} {
+		return "5"
+	})
+
+	test.Equal(InvalidBindingError(`unknown duration unit: "days"`), err)
+}
+
+func TestBind_CanFilterFields(t *testing.T) {
+	test := assert.New(t)
+
+	var plugin struct {
+		Name    string `bind:"yes"`
+		Version int    `required:"true"`
+		Port    int    `bind:"yes"`
+	}
+
+	err := Bind(&plugin, func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "metrics"
+		case "Port":
+			return "8080"
+		default:
+			return nil
+		}
+	}, FieldFilter(func(field reflect.StructField) bool {
+		return field.Tag.Get("bind") == "yes"
+	}))
+
+	test.NoError(err)
+	test.Equal("metrics", plugin.Name)
+	test.Equal(8080, plugin.Port)
+	test.Equal(0, plugin.Version)
+}
+
+func TestBind_CanBindFlags(t *testing.T) {
+	test := assert.New(t)
+
+	var role struct {
+		Permissions int `binding:"flags:read=1|write=2|delete=4"`
+		Defaults    int `binding:"flags:read=1|write=2|delete=4"`
+		Invalid     int `binding:"flags:read=1|write=2|delete=4"`
+	}
+
+	err := Bind(&role, func(key string) interface{} {
+		switch key {
+		case "Permissions":
+			return "read,delete"
+		case "Defaults":
+			return []string{"read", "write"}
+		case "Invalid":
+			return "read,execute"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{
+			BindingError{"Invalid", fmt.Errorf(`unknown flag: "execute"`)},
+		},
+		err,
+	)
+	test.Equal(5, role.Permissions)
+	test.Equal(3, role.Defaults)
+
+	var mask struct {
+		Small  uint8  `binding:"flags:a=1|b=128"`
+		Medium uint16 `binding:"flags:a=1|b=256"`
+		Large  uint   `binding:"flags:a=1|b=4294967296"`
+		Signed int64  `binding:"flags:a=1|b=4294967296"`
+	}
+
+	err = Bind(&mask, func(string) interface{} { return "a,b" })
+
+	test.NoError(err)
+	test.Equal(uint8(129), mask.Small)
+	test.Equal(uint16(257), mask.Medium)
+	test.Equal(uint(4294967297), mask.Large)
+	test.Equal(int64(4294967297), mask.Signed)
+
+	var overflow struct {
+		Small uint8 `binding:"flags:a=1|b=256"`
+	}
+
+	err = Bind(&overflow, func(string) interface{} { return "b" })
+
+	test.IsType(InvalidBindingError(""), err)
+	test.Equal(0, role.Invalid)
+}
+
+type testCodeError string
+
+func (err testCodeError) Error() string {
+	return "invalid age"
+}
+
+func (err testCodeError) Code() string {
+	return string(err)
+}
+
+func TestBindingError_ExposesCode(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Age    int `binding:"age"`
+		Height int
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		return "x"
+	}, Bindings{
+		"age": func(interface{}, string) (interface{}, error) {
+			return nil, fmt.Errorf("age: %w", testCodeError("ERR_INVALID_AGE"))
+		},
+	})
+
+	errs := err.(BindingErrors)
+
+	test.Equal("ERR_INVALID_AGE", errs.Field("Age").(BindingError).Code())
+	test.Equal("", errs.Field("Height").(BindingError).Code())
+}
+
+func TestBindValue_CanBindReflectValue(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name string
+	}
+
+	mapper := func(key string) interface{} {
+		return "John Doe"
+	}
+
+	err := BindValue(reflect.ValueOf(&user).Elem(), mapper)
+
+	test.NoError(err)
+	test.Equal("John Doe", user.Name)
+
+	err = BindValue(reflect.ValueOf(user), mapper)
+
+	test.Equal(InvalidBindingError(`output can not be set`), err)
+
+	err = BindValue(reflect.ValueOf(&user), mapper)
+
+	test.IsType(InvalidBindingError(""), err)
+
+	err = BindValue(reflect.Value{}, mapper)
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanBindTimeWithTimeZone(t *testing.T) {
+	test := assert.New(t)
+
+	var event struct {
+		CreatedAt time.Time
+		StartsAt  time.Time `binding:"time:2006-01-02 15:04;tz=America/New_York"`
+		EndsAt    time.Time `binding:"time:2006-01-02 15:04;tz=America/New_York"`
+	}
+
+	err := Bind(&event, func(key string) interface{} {
+		switch key {
+		case "CreatedAt":
+			return "2020-01-02T03:04:05Z"
+		case "StartsAt":
+			return "2020-01-02 10:00"
+		case "EndsAt":
+			return "tomorrow"
+		default:
+			return nil
+		}
+	})
+
+	test.Len(err, 1)
+	test.NotNil(err.(BindingErrors).Field("EndsAt"))
+
+	location, _ := time.LoadLocation("America/New_York")
+
+	test.True(
+		time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC).Equal(event.CreatedAt),
+	)
+	test.True(
+		time.Date(2020, 1, 2, 10, 0, 0, 0, location).Equal(event.StartsAt),
+	)
+	test.Equal("15:00", event.StartsAt.UTC().Format("15:04"))
+
+	var invalid struct {
+		StartsAt time.Time `binding:"time:2006-01-02;tz=Mars/Olympus"`
+	}
+
+	err = Bind(&invalid, func(key string) interface{} {
+		return "2020-01-02"
+	})
+
+	test.Equal(InvalidBindingError(`unknown time zone: "Mars/Olympus"`), err)
+}
+
+func TestBindWithReport_ReturnsProvenance(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name string
+		Age  int8 `form:"age"`
+	}
+
+	report, err := BindWithReport(&user, func(key string) interface{} {
+		switch key {
+		case "age":
+			return "27"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal(
+		map[string]Provenance{
+			"age": {Name: "age", RawValue: "27", Binding: "int:8"},
+		},
+		report.Provenance,
+	)
+}
+
+func TestBind_CanTransformKeys(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Host string `form:"host"`
+		Port int    `form:"port" required:"true"`
+	}
+
+	var keys []string
+
+	err := Bind(&config, func(key string) interface{} {
+		keys = append(keys, key)
+
+		if key == "DB.HOST" {
+			return "localhost"
+		}
+
+		return nil
+	}, Prefix("db."), KeyFunc(strings.ToUpper))
+
+	test.Equal(BindingErrors{RequiredError{"port"}}, err)
+	test.Equal([]string{"DB.HOST", "DB.PORT"}, keys)
+	test.Equal("localhost", config.Host)
+}
+
+func TestBind_CanSkipFieldsWithDashName(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name     string
+		Password string `form:"-"`
+		Token    string `json:"-"`
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		return key
+	})
+
+	test.NoError(err)
+	test.Equal("Name", user.Name)
+	test.Empty(user.Password)
+	test.Empty(user.Token)
+}
+
+func TestBind_CanRequireBindableFields(t *testing.T) {
+	test := assert.New(t)
+
+	var secret struct {
+		name     string
+		Password string `form:"-"`
+	}
+
+	mapper := func(key string) interface{} {
+		return nil
+	}
+
+	err := Bind(&secret, mapper)
+
+	test.NoError(err)
+
+	err = Bind(&secret, mapper, RequireBindableFields(true))
+
+	test.IsType(InvalidBindingError(""), err)
+
+	var user struct {
+		Name string
+	}
+
+	err = Bind(&user, mapper, RequireBindableFields(true))
+
+	test.NoError(err)
+}
+
+func TestBind_CanBindIntKeyedMaps(t *testing.T) {
+	test := assert.New(t)
+
+	var form struct {
+		Answers map[int]string
+		Scores  map[int8]int
+	}
+
+	err := Bind(&form, func(key string) interface{} {
+		switch key {
+		case "Answers":
+			return map[string]string{"1": "yes", "3": "no", "x": "maybe"}
+		case "Scores":
+			return map[string]string{"1": "10", "1000": "20"}
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		[]string{"Answers[x]", "Scores[1000]"},
+		err.(BindingErrors).Fields(),
+	)
+
+	var rangeError RangeError
+
+	test.True(errors.As(err.(BindingErrors).Field("Scores[1000]"), &rangeError))
+	test.Equal(map[int]string{1: "yes", 3: "no"}, form.Answers)
+	test.Equal(map[int8]int{1: 10}, form.Scores)
+}
+
+func TestBind_CanUseWithBinding(t *testing.T) {
+	test := assert.New(t)
+
+	var contract struct {
+		ExpiresIn time.Duration `binding:"hours"`
+	}
+
+	hours := func(data interface{}, _ string) (interface{}, error) {
+		return time.ParseDuration(data.(string) + "h")
+	}
+
+	err := Bind(&contract, func(key string) interface{} {
+		return "2"
+	}, WithBinding("hours", hours))
+
+	test.NoError(err)
+	test.Equal(2*time.Hour, contract.ExpiresIn)
+}
+
+func TestBind_ChecksRequiredNestedStructs(t *testing.T) {
+	test := assert.New(t)
+
+	type address struct {
+		City string `required:"true"`
+		Zip  string
+	}
+
+	var user struct {
+		Home    *address `required:"true"`
+		Office  address  `required:"true"`
+		Billing *address
+		Work    address
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Office.Zip", "Work.City":
+			return "10001"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{
+			RequiredError{"Home"},
+			RequiredError{"Office.City"},
+		},
+		err,
+	)
+	test.Nil(user.Home)
+	test.Nil(user.Billing)
+	test.Equal("10001", user.Office.Zip)
+	test.Equal("10001", user.Work.City)
+}
+
+func TestBindings_RegisterAndMerge(t *testing.T) {
+	test := assert.New(t)
+
+	var (
+		bindHours = func(data interface{}, _ string) (interface{}, error) {
+			return time.ParseDuration(data.(string) + "h")
+		}
+		bindMinutes = func(data interface{}, _ string) (interface{}, error) {
+			return time.ParseDuration(data.(string) + "m")
+		}
+	)
+
+	var hours Bindings
+
+	hours = hours.Register("hours", bindHours).Register("minutes", bindHours)
+	minutes := Bindings{}.Register("minutes", bindMinutes)
+
+	merged := hours.Merge(minutes)
+
+	test.Len(hours, 2)
+	test.Len(minutes, 1)
+	test.Len(merged, 2)
+
+	var contract struct {
+		ExpiresIn time.Duration `binding:"minutes"`
+	}
+
+	err := Bind(&contract, func(key string) interface{} {
+		return "2"
+	}, merged)
+
+	test.NoError(err)
+	test.Equal(2*time.Minute, contract.ExpiresIn)
+
+	err = Bind(&contract, func(key string) interface{} {
+		return "2"
+	}, hours)
+
+	test.NoError(err)
+	test.Equal(2*time.Hour, contract.ExpiresIn)
+}
+
+func TestBind_CanBindBoolsFromNativeValues(t *testing.T) {
+	test := assert.New(t)
+
+	var settings struct {
+		Enabled  bool
+		Visible  bool
+		Archived bool
+		Pinned   *bool
+		Locked   bool
+	}
+
+	settings.Visible = true
+
+	err := Bind(&settings, func(key string) interface{} {
+		switch key {
+		case "Enabled":
+			return true
+		case "Visible":
+			return float64(0)
+		case "Archived":
+			return 1
+		case "Pinned":
+			return false
+		case "Locked":
+			return float64(2)
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		BindingErrors{
+			BindingError{"Locked", fmt.Errorf("invalid bool value: 2")},
+		},
+		err,
+	)
+	test.True(settings.Enabled)
+	test.False(settings.Visible)
+	test.True(settings.Archived)
+
+	if test.NotNil(settings.Pinned) {
+		test.False(*settings.Pinned)
+	}
+}
+
+func TestBind_CanCheckNamesStrictly(t *testing.T) {
+	test := assert.New(t)
+
+	type user struct {
+		Name     string `name:"name"`
+		Password string `name:"password" form:"-"`
+		Age      int
+	}
+
+	var (
+		output user
+
+		mapper = func(key string) interface{} {
+			return "John Doe"
+		}
+
+		fieldNameFunc = FieldNameFunc(func(field reflect.StructField) string {
+			if field.Tag.Get("form") == "-" {
+				return ""
+			}
+
+			return field.Tag.Get("name")
+		})
+	)
+
+	err := Bind(&output, mapper, fieldNameFunc)
+
+	test.NoError(err)
+
+	err = Bind(&output, mapper, fieldNameFunc, StrictNames(true))
+
+	test.Equal(
+		InvalidBindingError(`field binding.user.Age has empty name`),
+		err,
+	)
+}
+
+func TestBind_CanBindDurationsAsSeconds(t *testing.T) {
+	test := assert.New(t)
+
+	var timeouts struct {
+		Read  time.Duration `binding:"duration:seconds"`
+		Write time.Duration `binding:"duration:seconds"`
+	}
+
+	err := Bind(&timeouts, func(key string) interface{} {
+		switch key {
+		case "Read":
+			return "1.5"
+		default:
+			return "1s"
+		}
+	})
+
+	var syntaxError SyntaxError
+
+	test.True(errors.As(err.(BindingErrors).Field("Write"), &syntaxError))
+	test.Equal(1500*time.Millisecond, timeouts.Read)
+	test.Equal(time.Duration(0), timeouts.Write)
+}
+
+func TestBind_ReportsDurationsAsSecondsOutOfRange(t *testing.T) {
+	test := assert.New(t)
+
+	cases := map[string]interface{}{
+		"NaN":                  SyntaxError{},
+		"Inf":                  RangeError{},
+		"-Inf":                 RangeError{},
+		"1e20":                 RangeError{},
+		"-1e20":                RangeError{},
+		"9223372036.854775808": RangeError{},
+	}
+
+	for value, expected := range cases {
+		var timeouts struct {
+			Read time.Duration `binding:"duration:seconds"`
+		}
+
+		err := Bind(&timeouts, func(string) interface{} { return value })
+
+		test.Error(err, value)
+		cause := errors.Unwrap(err.(BindingErrors).Field("Read"))
+
+		test.IsType(expected, cause, value)
+		test.Equal(time.Duration(0), timeouts.Read, value)
+	}
+
+	bindings := newConfig(nil).bindings
+
+	duration, err := bindings["duration"]("-9223372036.854775808", "seconds")
+
+	test.NoError(err)
+	test.Equal(time.Duration(math.MinInt64), duration)
+}
+
+func TestBind_AcceptsRequiredTagSpellings(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		A string `required:"true"`
+		B string `required:"TRUE"`
+		C string `required:"1"`
+		D string `required:"t"`
+		E string `required:"false"`
+		F string `required:"yes"`
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		return nil
+	})
+
+	test.Equal([]string{"A", "B", "C", "D"}, err.(BindingErrors).Fields())
+}
+
+func TestBindDiff_ReturnsChangedFields(t *testing.T) {
+	test := assert.New(t)
+
+	type user struct {
+		Name   string `form:"name"`
+		Age    int    `form:"age"`
+		Height int    `form:"height"`
+		Tags   []string
+	}
+
+	baseline := user{Name: "John Doe", Age: 27, Tags: []string{"a"}}
+	output := baseline
+
+	changed, err := BindDiff(baseline, &output, func(key string) interface{} {
+		switch key {
+		case "name":
+			return "John Doe"
+		case "age":
+			return "28"
+		case "height":
+			return "tall"
+		case "Tags":
+			return "a,b"
+		default:
+			return nil
+		}
+	})
+
+	test.Error(err)
+	test.NotNil(err.(BindingErrors).Field("height"))
+	test.Equal([]string{"age", "Tags"}, changed)
+	test.Equal(27, baseline.Age)
+
+	var other struct {
+		Name string
+	}
+
+	_, err = BindDiff(other, &output, func(key string) interface{} {
+		return nil
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanBindSlicesOfStructPointers(t *testing.T) {
+	test := assert.New(t)
+
+	type item struct {
+		Name  string
+		Count int
+	}
+
+	var order struct {
+		Items []*item
+	}
+
+	values := map[string]interface{}{
+		"Items[0].Name":  "apple",
+		"Items[0].Count": "2",
+		"Items[1].Name":  "pear",
+		"Items[3].Name":  "plum",
+	}
+
+	mapper := func(key string) interface{} {
+		return values[key]
+	}
+
+	err := Bind(&order, mapper)
+
+	test.NoError(err)
+	test.Equal([]*item{{"apple", 2}, {"pear", 0}}, order.Items)
+
+	err = Bind(&order, mapper, SliceGaps(1))
+
+	test.NoError(err)
+	test.Equal(
+		[]*item{{"apple", 2}, {"pear", 0}, nil, {"plum", 0}},
+		order.Items,
+	)
+
+	err = Bind(&order, func(key string) interface{} {
+		if key == "Items" {
+			return []interface{}{
+				map[string]interface{}{"Name": "apple", "Count": "x"},
+				nil,
+				map[string]interface{}{"Name": "plum"},
+			}
+		}
+
+		return nil
+	}, NestedMaps(true))
+
+	test.Equal([]string{"Items[0].Count"}, err.(BindingErrors).Fields())
+	test.Equal([]*item{{"apple", 0}, nil, {"plum", 0}}, order.Items)
+}
+
+func TestBind_LimitsSlicesOfStructsForAlwaysMappedKeys(t *testing.T) {
+	test := assert.New(t)
+
+	var order struct {
+		Items []struct {
+			Name string
+		}
+	}
+
+	err := Bind(&order, func(string) interface{} { return "" })
+
+	test.EqualError(
+		err,
+		"Items — 10001 items given, but at most 10000 allowed",
+	)
+	test.Nil(order.Items)
+
+	var counts struct {
+		Items []struct {
+			Count int
+		}
+	}
+
+	err = Bind(&counts, func(string) interface{} { return "" }, FailFast(true))
+
+	test.Equal([]string{"Items[0].Count"}, err.(BindingErrors).Fields())
+}
+
+func TestBind_LimitsSlicesOfStructsUsingTags(t *testing.T) {
+	test := assert.New(t)
+
+	var order struct {
+		Items []struct {
+			Name string
+		} `minitems:"2" maxitems:"3"`
+	}
+
+	mapper := func(count int) MapFunc {
+		return func(key string) interface{} {
+			var index int
+
+			_, err := fmt.Sscanf(key, "Items[%d].Name", &index)
+			if err != nil || index >= count {
+				return nil
+			}
+
+			return "item"
+		}
+	}
+
+	err := Bind(&order, mapper(100))
+
+	test.EqualError(err, "Items — 4 items given, but at most 3 allowed")
+	test.Nil(order.Items)
+
+	err = Bind(&order, mapper(1))
+
+	test.EqualError(err, "Items — 1 items given, but at least 2 required")
+	test.Nil(order.Items)
+
+	err = Bind(&order, mapper(3))
+
+	test.NoError(err)
+	test.Len(order.Items, 3)
+}
+
+func TestBind_CanLimitErrors(t *testing.T) {
+	test := assert.New(t)
+
+	var form struct {
+		A    string `required:"true"`
+		B    string `required:"true"`
+		C    string `required:"true"`
+		List []int
+	}
+
+	var keys []string
+
+	err := Bind(&form, func(key string) interface{} {
+		keys = append(keys, key)
+
+		return nil
+	}, MaxErrors(2))
+
+	test.Equal(
+		BindingErrors{
+			RequiredError{"A"},
+			RequiredError{"B"},
+			TruncatedError{2},
+		},
+		err,
+	)
+	test.Equal([]string{"A", "B"}, keys)
+
+	err = Bind(&form, func(key string) interface{} {
+		if key == "List" {
+			return "a,b,c"
+		}
+
+		return "x"
+	}, MaxErrors(2))
+
+	test.Equal(
+		[]string{"List[0]", "List[1]"},
+		err.(BindingErrors).Fields(),
+	)
+	test.Len(err, 3)
+
+	err = Bind(&form, func(key string) interface{} {
+		if key == "List" {
+			return "1"
+		}
+
+		return nil
+	}, MaxErrors(4))
+
+	test.Equal([]string{"A", "B", "C"}, err.(BindingErrors).Fields())
+	test.Len(err, 3)
+}
+
+func TestBind_BindsEmptyTimeAsZero(t *testing.T) {
+	test := assert.New(t)
+
+	var event struct {
+		StartsAt time.Time
+		EndsAt   *time.Time
+		Deadline time.Time `required:"true"`
+	}
+
+	event.StartsAt = time.Now()
+
+	mapper := func(key string) interface{} {
+		return ""
+	}
+
+	err := Bind(&event, mapper)
+
+	test.NoError(err)
+	test.True(event.StartsAt.IsZero())
+	test.Nil(event.EndsAt)
+	test.True(event.Deadline.IsZero())
+
+	err = Bind(&event, mapper, EmptyAsAbsent(true))
+
+	test.Equal(BindingErrors{RequiredError{"Deadline"}}, err)
+}
+
+func TestDecoder_BindsWithOptions(t *testing.T) {
+	test := assert.New(t)
+
+	decoder := NewDecoder(Prefix("user."), FailFast(true))
+
+	var user struct {
+		Name string `required:"true"`
+		Age  int    `required:"true"`
+	}
+
+	err := decoder.Bind(&user, func(key string) interface{} {
+		return nil
+	})
+
+	test.Equal(BindingErrors{RequiredError{"Name"}}, err)
+
+	err = decoder.Bind(&user, func(key string) interface{} {
+		return nil
+	}, FailFast(false))
+
+	test.Len(err, 2)
+}
+
+func TestDecoder_ExposesConfig(t *testing.T) {
+	test := assert.New(t)
+
+	decoder := NewDecoder(
+		WithBinding("hours", func(interface{}, string) (interface{}, error) {
+			return nil, nil
+		}),
+		SliceSeparator(";"),
+		StrictNames(true),
+	)
+
+	config := decoder.Config()
+
+	test.Contains(config.Bindings, "hours")
+	test.Contains(config.Bindings, "int")
+	test.Equal(";", config.SliceSeparator)
+	test.True(config.StrictNames)
+	test.False(config.FailFast)
+	test.False(config.HasKeyFunc)
+	test.Equal(
+		"github.com/seletskiy/binding-go.getFieldName",
+		config.FieldNameFunc,
+	)
+}
+
+func TestBind_CanCanonicalizeHeaderKeys(t *testing.T) {
+	test := assert.New(t)
+
+	var request struct {
+		Headers http.Header         `binding:"header"`
+		Single  map[string]string   `binding:"header"`
+		Raw     map[string][]string `binding:"string"`
+	}
+
+	headers := map[string][]string{
+		"content-type": {"text/plain"},
+		"Content-Type": {"text/html"},
+		"x-request-id": {"1"},
+	}
+
+	err := Bind(&request, func(key string) interface{} {
+		if key == "Single" {
+			return map[string]string{"x-request-id": "1"}
+		}
+
+		return headers
+	})
+
+	test.NoError(err)
+	test.Equal(
+		http.Header{
+			"Content-Type": {"text/html", "text/plain"},
+			"X-Request-Id": {"1"},
+		},
+		request.Headers,
+	)
+	test.Equal(map[string]string{"X-Request-Id": "1"}, request.Single)
+	test.Equal(headers, request.Raw)
+}
+
+func TestBind_WrapsFieldErrorsWithName(t *testing.T) {
+	test := assert.New(t)
+
+	binder := &binder{config: newConfig(nil)}
+
+	binder.addError("Age", fmt.Errorf("too old"))
+	binder.addError("Name", RequiredError{name: "Name"})
+	binder.addErrors("Tags", BindingErrors{
+		BindingError{name: "Tags[1]", cause: fmt.Errorf("too long")},
+		fmt.Errorf("too many"),
+	})
+
+	test.Equal(
+		BindingErrors{
+			BindingError{"Age", fmt.Errorf("too old")},
+			RequiredError{"Name"},
+			BindingError{"Tags[1]", fmt.Errorf("too long")},
+			BindingError{"Tags", fmt.Errorf("too many")},
+		},
+		binder.errors,
+	)
+	test.NotNil(binder.errors.Field("Age"))
+	test.NotNil(binder.errors.Field("Tags"))
+}
+
+func TestBind_CanBindByProtobufFieldNumbers(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		ID    int64  `protobuf:"varint,1,opt,name=id,proto3"`
+		Name  string `protobuf:"bytes,2,opt,name=name,proto3"`
+		Email string `protobuf:"bytes,x,opt,name=email,proto3"`
+		state int
+	}
+
+	err := Bind(&user, NumberedMapper(map[int]interface{}{
+		1: "42",
+		2: "John Doe",
+		3: "john@example.com",
+	}), FieldNameFunc(ProtobufFieldNumber))
+
+	test.NoError(err)
+	test.Equal(int64(42), user.ID)
+	test.Equal("John Doe", user.Name)
+	test.Empty(user.Email)
+	test.Equal(0, user.state)
+}
+
+func TestBind_CanUseBindingAliases(t *testing.T) {
+	test := assert.New(t)
+
+	var query struct {
+		Limit  int64  `binding:"integer:64"`
+		Offset int64  `binding:"long:64"`
+		Sort   string `binding:"text"`
+	}
+
+	err := Bind(&query, func(key string) interface{} {
+		switch key {
+		case "Limit":
+			return "10"
+		case "Offset":
+			return "20"
+		default:
+			return "name"
+		}
+	}, Aliases{
+		"integer": "int",
+		"long":    "integer",
+		"text":    "str",
+	}, WithBinding("str", bindString))
+
+	test.NoError(err)
+	test.Equal(int64(10), query.Limit)
+	test.Equal(int64(20), query.Offset)
+	test.Equal("name", query.Sort)
+}
+
+func TestBind_CanUseAliasesOfFieldBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var request struct {
+		Filter map[string]int    `binding:"j"`
+		Labels map[string]string `binding:"pairs"`
+	}
+
+	values := map[string]interface{}{
+		"Filter": `{"age":18}`,
+		"Labels": "env=prod",
+	}
+
+	err := Bind(
+		&request,
+		func(key string) interface{} { return values[key] },
+		Aliases{"j": "json", "pairs": "keyvalues", "keyvalues": "kv"},
+	)
+
+	test.NoError(err)
+	test.Equal(map[string]int{"age": 18}, request.Filter)
+	test.Equal(map[string]string{"env": "prod"}, request.Labels)
+}
+
+func TestBind_ReportsCircularAndUnknownBindingAliases(t *testing.T) {
+	test := assert.New(t)
+
+	var query struct {
+		Limit int `binding:"integer"`
+	}
+
+	mapper := func(string) interface{} {
+		return "10"
+	}
+
+	err := Bind(&query, mapper, Aliases{"integer": "integer"})
+	test.Equal(InvalidBindingError(`binding alias "integer" is circular`), err)
+
+	err = Bind(
+		&query,
+		mapper,
+		Aliases{"integer": "number", "number": "integer"},
+	)
+	test.Equal(InvalidBindingError(`binding alias "integer" is circular`), err)
+
+	err = Bind(&query, mapper, Aliases{"integer": "number"})
+	test.Equal(
+		InvalidBindingError(
+			`binding alias "integer" refers to unknown binding "number"`,
+		),
+		err,
+	)
+}
+
+func TestBind_CanUseDefaultValues(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Username    string
+		DisplayName string `default:"$Nickname"`
+		Nickname    string `default:"$Username"`
+		Age         int    `default:"18" required:"true"`
+		Role        string `default:"guest"`
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		if key == "Username" {
+			return "john"
+		}
+
+		return nil
+	})
+
+	test.NoError(err)
+	test.Equal("john", user.DisplayName)
+	test.Equal("john", user.Nickname)
+	test.Equal(18, user.Age)
+	test.Equal("guest", user.Role)
+
+	err = Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Nickname":
+			return "johnny"
+		case "Age":
+			return "20"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal("johnny", user.DisplayName)
+	test.Equal(20, user.Age)
+}
+
+func TestBind_CanUseDefaultsMap(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Database struct {
+			Host    string `default:"127.0.0.1"`
+			Port    int
+			Timeout time.Duration
+		}
+		Debug bool `default:"true"`
+	}
+
+	mapper := func(key string) interface{} {
+		if key == "Debug" {
+			return "false"
+		}
+
+		return nil
+	}
+
+	err := Bind(&config, mapper, Defaults{
+		"Database.Host":    "db.local",
+		"Database.Port":    "5432",
+		"Database.Timeout": 5 * time.Second,
+		"Debug":            "true",
+	})
+
+	test.NoError(err)
+	test.Equal("db.local", config.Database.Host)
+	test.Equal(5432, config.Database.Port)
+	test.Equal(5*time.Second, config.Database.Timeout)
+	test.False(config.Debug)
+}
+
+func TestBind_ReportsDefaultsOfWrongType(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Port int
+	}
+
+	err := Bind(&config, func(string) interface{} { return nil }, Defaults{
+		"Port": 5 * time.Second,
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanBindURLEncodedValues(t *testing.T) {
+	test := assert.New(t)
+
+	var query struct {
+		Search string `binding:"urldecode"`
+		Page   int    `binding:"urldecode:int"`
+		Sort   string `binding:"urldecode"`
+	}
+
+	values := map[string]interface{}{
+		"Search": "hello%20world+%26+more",
+		"Page":   "%32",
+		"Sort":   "%zz",
+	}
+
+	err := Bind(&query, func(key string) interface{} { return values[key] })
+
+	test.EqualError(err, `Sort — invalid URL escape "%zz"`)
+	test.Equal("hello world & more", query.Search)
+	test.Equal(2, query.Page)
+}
+
+type testBinaryVersion struct {
+	Major, Minor byte
+	Source       string
+}
+
+func (version *testBinaryVersion) UnmarshalBinary(data []byte) error {
+	if len(data) != 2 {
+		return errors.New("version should be 2 bytes long")
+	}
+
+	*version = testBinaryVersion{data[0], data[1], "binary"}
+
+	return nil
+}
+
+func (version *testBinaryVersion) UnmarshalText(data []byte) error {
+	_, err := fmt.Sscanf(string(data), "%d.%d", &version.Major, &version.Minor)
+	version.Source = "text"
+
+	return err
+}
+
+type testTextEndpoint struct {
+	Host string
+	Port int
+}
+
+func (endpoint *testTextEndpoint) UnmarshalText(data []byte) error {
+	_, err := fmt.Sscanf(
+		strings.Replace(string(data), ":", " ", 1),
+		"%s %d",
+		&endpoint.Host,
+		&endpoint.Port,
+	)
+
+	return err
+}
+
+func TestBind_BindsNestedStructsImplementingTextUnmarshaler(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Server testTextEndpoint
+		Proxy  testTextEndpoint `binding:"unmarshal"`
+	}
+
+	values := map[string]interface{}{
+		"Server":      "example.com:443",
+		"Server.Host": "localhost",
+		"Server.Port": "80",
+		"Proxy":       "proxy:8080",
+		"Proxy.Host":  "ignored",
+	}
+
+	err := Bind(&config, func(key string) interface{} { return values[key] })
+
+	test.NoError(err)
+	test.Equal(testTextEndpoint{"localhost", 80}, config.Server)
+	test.Equal(testTextEndpoint{"proxy", 8080}, config.Proxy)
+}
+
+type testBinaryChecksum [4]byte
+
+func (checksum *testBinaryChecksum) UnmarshalBinary(data []byte) error {
+	if len(data) != len(checksum) {
+		return errors.New("checksum should be 4 bytes long")
+	}
+
+	copy(checksum[:], data)
+
+	return nil
+}
+
+func TestBind_CanBindUnmarshalers(t *testing.T) {
+	test := assert.New(t)
+
+	var release struct {
+		Version  testBinaryVersion  `binding:"unmarshal"`
+		Previous *testBinaryVersion `binding:"unmarshal"`
+		Checksum testBinaryChecksum `binding:"unmarshal:hex"`
+		Encoded  testBinaryChecksum `binding:"unmarshal:base64"`
+		Raw      testBinaryChecksum
+		Invalid  testBinaryVersion `binding:"unmarshal"`
+	}
+
+	values := map[string]interface{}{
+		"Version":  "1.2",
+		"Previous": []byte{1, 1},
+		"Checksum": "deadbeef",
+		"Encoded":  "3q2+7w==",
+		"Raw":      "abcd",
+		"Invalid":  []byte{1},
+	}
+
+	err := Bind(&release, func(key string) interface{} { return values[key] })
+
+	test.EqualError(err, "Invalid — version should be 2 bytes long")
+	test.Equal(testBinaryVersion{1, 2, "text"}, release.Version)
+	test.Equal(&testBinaryVersion{1, 1, "binary"}, release.Previous)
+	test.Equal(testBinaryChecksum{0xde, 0xad, 0xbe, 0xef}, release.Checksum)
+	test.Equal(testBinaryChecksum{0xde, 0xad, 0xbe, 0xef}, release.Encoded)
+	test.Equal(testBinaryChecksum{'a', 'b', 'c', 'd'}, release.Raw)
+}
+
+func TestBind_CanBindUnmarshalersWithoutExportedFields(t *testing.T) {
+	test := assert.New(t)
+
+	var server struct {
+		Addr  netip.Addr
+		Route *netip.Prefix
+	}
+
+	values := map[string]interface{}{
+		"Addr":  "10.0.0.1",
+		"Route": "10.0.0.0/8",
+	}
+
+	err := Bind(&server, func(key string) interface{} { return values[key] })
+
+	test.NoError(err)
+	test.Equal(netip.MustParseAddr("10.0.0.1"), server.Addr)
+	test.Equal(netip.MustParsePrefix("10.0.0.0/8"), *server.Route)
+
+	var cluster struct {
+		Peers []netip.Addr
+	}
+
+	err = Bind(&cluster, func(key string) interface{} { return "::1" })
+
+	test.EqualError(
+		err,
+		"binding for struct { Peers []netip.Addr }.Peers is not specified "+
+			"and type netip.Addr has no default binding, specify it using "+
+			"binding tag or TypeBindings option",
+	)
+}
+
+type testReferenceProfile struct {
+	Login string
+	email string `form:"Email" default:"$Login" setter:"SetEmail"`
+}
+
+func (profile *testReferenceProfile) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid email: %q", email)
+	}
+
+	profile.email = strings.ToLower(email)
+
+	return nil
+}
+
+func TestBind_UsesSettersForDefaultReferences(t *testing.T) {
+	test := assert.New(t)
+
+	var profile testReferenceProfile
+
+	err := Bind(&profile, func(key string) interface{} {
+		if key == "Login" {
+			return "John@Example.com"
+		}
+
+		return nil
+	})
+
+	test.NoError(err)
+	test.Equal("john@example.com", profile.email)
+
+	err = Bind(&profile, func(key string) interface{} {
+		if key == "Login" {
+			return "john"
+		}
+
+		return nil
+	})
+
+	test.EqualError(err, `Email — invalid email: "john"`)
+}
+
+func TestBind_ReportsUnexportedDefaultReferences(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Login string
+		email string `form:"Email" default:"$Login"`
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		if key == "Login" {
+			return "john"
+		}
+
+		return nil
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+	test.Empty(user.email)
+}
+
+func TestBind_ReportsCircularDefaultReferences(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		DisplayName string `default:"$Nickname"`
+		Nickname    string `default:"$DisplayName"`
+	}
+
+	err := Bind(&user, func(string) interface{} {
+		return nil
+	})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+	test.Contains(err.Error(), "circular")
+
+	var profile struct {
+		DisplayName string `default:"$Login"`
+	}
+
+	err = Bind(&profile, func(string) interface{} {
+		return nil
+	})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+	test.Contains(err.Error(), "unknown field")
+}
+
+func TestBind_CanBindComplexNumbers(t *testing.T) {
+	test := assert.New(t)
+
+	var signal struct {
+		Impedance complex128
+		Gain      complex64
+		Phasor    complex128 `binding:"complex:polar"`
+		Current   complex128 `binding:"complex:polar;unit=deg"`
+		Voltage   complex128 `binding:"complex:polar"`
+	}
+
+	err := Bind(&signal, func(key string) interface{} {
+		switch key {
+		case "Impedance":
+			return "3+4i"
+		case "Gain":
+			return "1.5-2i"
+		case "Phasor":
+			return "2∠90deg"
+		case "Current":
+			return "1∠180"
+		default:
+			return "2@45deg"
+		}
+	})
+
+	test.Equal(complex(3, 4), signal.Impedance)
+	test.Equal(complex64(complex(1.5, -2)), signal.Gain)
+	test.InDelta(0, real(signal.Phasor), 1e-9)
+	test.InDelta(2, imag(signal.Phasor), 1e-9)
+	test.InDelta(-1, real(signal.Current), 1e-9)
+	test.InDelta(0, imag(signal.Current), 1e-9)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.NotNil(err.(BindingErrors).Field("Voltage"))
+}
+
+func TestBind_CanBindMapsOfStructs(t *testing.T) {
+	test := assert.New(t)
+
+	type ServiceConfig struct {
+		Host string
+		Port int `required:"true"`
+	}
+
+	var config struct {
+		Services map[string]ServiceConfig
+		Backends map[string]*ServiceConfig
+	}
+
+	err := Bind(&config, func(key string) interface{} {
+		switch key {
+		case "Services":
+			return map[string]map[string]interface{}{
+				"web": {"Host": "localhost", "Port": "80"},
+				"api": {"Host": "localhost", "Port": "http"},
+				"db":  {"Host": "localhost"},
+			}
+		default:
+			return map[string]interface{}{
+				"cache": map[string]interface{}{"Port": "6379"},
+			}
+		}
+	})
+
+	test.Equal(ServiceConfig{"localhost", 80}, config.Services["web"])
+	test.NotContains(config.Services, "api")
+	test.NotContains(config.Services, "db")
+	test.Equal(&ServiceConfig{Port: 6379}, config.Backends["cache"])
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.IsType(BindingError{}, err.(BindingErrors).Field("Services[api].Port"))
+	test.IsType(RequiredError{}, err.(BindingErrors).Field("Services[db].Port"))
+}
+
+func TestBind_CanUseExtendedBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var server struct {
+		Port     uint16
+		Workers  uint
+		Endpoint url.URL
+		Proxy    *url.URL
+		Address  net.IP
+		Timeout  time.Duration
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Port":
+			return "8080"
+		case "Workers":
+			return "4"
+		case "Endpoint":
+			return "https://example.com/api"
+		case "Proxy":
+			return "http://proxy:3128"
+		case "Address":
+			return "10.0.0.1"
+		default:
+			return "5s"
+		}
+	}
+
+	err := Bind(&server, mapper)
+	test.EqualError(
+		err,
+		"binding uint for struct { Port uint16; Workers uint; "+
+			"Endpoint url.URL; Proxy *url.URL; Address net.IP; "+
+			"Timeout time.Duration }.Port is not registered, "+
+			"it's provided by ExtendedBindings option",
+	)
+	test.IsType(InvalidBindingError(""), err)
+
+	err = Bind(&server, mapper, ExtendedBindings())
+	test.NoError(err)
+	test.Equal(uint16(8080), server.Port)
+	test.Equal(uint(4), server.Workers)
+	test.Equal("example.com", server.Endpoint.Host)
+	test.Equal("proxy:3128", server.Proxy.Host)
+	test.Equal(net.ParseIP("10.0.0.1"), server.Address)
+	test.Equal(5*time.Second, server.Timeout)
+
+	err = Bind(&server, func(key string) interface{} {
+		switch key {
+		case "Port":
+			return "65536"
+		case "Address":
+			return "10.0.0"
+		default:
+			return nil
+		}
+	}, ExtendedBindings())
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.IsType(RangeError{}, errors.Unwrap(err.(BindingErrors).Field("Port")))
+	test.NotNil(err.(BindingErrors).Field("Address"))
+}
+
+type dynamicTarget struct {
+	values sync.Map
+}
+
+func (target *dynamicTarget) FieldNames() []string {
+	return []string{"name", "age", "email"}
+}
+
+func (target *dynamicTarget) SetField(name string, value interface{}) error {
+	if name == "age" {
+		age, err := strconv.Atoi(value.(string))
+		if err != nil {
+			return err
+		}
+
+		value = age
+	}
+
+	target.values.Store(name, value)
+
+	return nil
+}
+
+func TestBind_CanBindIntoFieldSetter(t *testing.T) {
+	test := assert.New(t)
+
+	target := &dynamicTarget{}
+
+	err := Bind(target, func(key string) interface{} {
+		switch key {
+		case "name":
+			return "John Doe"
+		case "age":
+			return "XXX"
+		default:
+			return nil
+		}
+	})
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.NotNil(err.(BindingErrors).Field("age"))
+
+	name, _ := target.values.Load("name")
+	test.Equal("John Doe", name)
+
+	_, ok := target.values.Load("email")
+	test.False(ok)
+
+	err = Bind(target, func(key string) interface{} {
+		return "42"
+	})
+
+	test.NoError(err)
+
+	age, _ := target.values.Load("age")
+	test.Equal(42, age)
+}
+
+func TestBind_CanBindLocalizedNumbers(t *testing.T) {
+	test := assert.New(t)
+
+	locales := Locales{"in": {Thousands: []string{","}, Decimal: "."}}
+
+	var order struct {
+		Total    float64 `binding:"float:64;locale=de"`
+		Price    float64 `binding:"float:64;locale=en"`
+		Quantity int     `binding:"int;locale=fr"`
+		Budget   int64   `binding:"int:64;locale=in"`
+		Discount float64 `binding:"float:64;locale=de"`
+	}
+
+	err := Bind(&order, func(key string) interface{} {
+		switch key {
+		case "Total":
+			return "1.234,56"
+		case "Price":
+			return "1,234.56"
+		case "Quantity":
+			return "12 000"
+		case "Budget":
+			return "1,00,000"
+		default:
+			return "1,2,3"
+		}
+	}, locales)
+
+	test.Equal(1234.56, order.Total)
+	test.Equal(1234.56, order.Price)
+	test.Equal(12000, order.Quantity)
+	test.Equal(int64(100000), order.Budget)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.NotNil(err.(BindingErrors).Field("Discount"))
+
+	var invoice struct {
+		Total float64 `binding:"float:64;locale=xx"`
+	}
+
+	err = Bind(&invoice, func(string) interface{} {
+		return "1"
+	})
+
+	test.Equal(InvalidBindingError(`unknown locale: "xx"`), err)
+
+	var budget struct {
+		Total int64 `binding:"int:64;locale=in"`
+	}
+
+	err = Bind(&budget, func(string) interface{} {
+		return "1,00,000"
+	})
+
+	test.Equal(InvalidBindingError(`unknown locale: "in"`), err)
+}
+
+func TestBind_CanBindDateTimeFromTwoKeys(t *testing.T) {
+	test := assert.New(t)
+
+	var event struct {
+		StartsAt time.Time  `binding:"datetime:date=event_date,time=event_time"`
+		EndsAt   *time.Time `binding:"datetime:date=end_date,time=end_time"`
+		Reminder time.Time  `binding:"datetime:date=reminder_date,time=reminder_time" required:"true"`
+		Deadline time.Time  `binding:"datetime:date=deadline_date,time=deadline_time"`
+	}
+
+	values := map[string]string{
+		"event_date":    "2024-03-15",
+		"event_time":    "18:30",
+		"end_date":      "2024-03-15",
+		"end_time":      "22:00:30",
+		"deadline_date": "2024-03-14",
+	}
+
+	err := Bind(&event, func(key string) interface{} {
+		if value, ok := values[key]; ok {
+			return value
+		}
+
+		return nil
+	})
+
+	test.Equal(time.Date(2024, 3, 15, 18, 30, 0, 0, time.UTC), event.StartsAt)
+	test.Equal(time.Date(2024, 3, 15, 22, 0, 30, 0, time.UTC), *event.EndsAt)
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.IsType(RequiredError{}, err.(BindingErrors).Field("Reminder"))
+	test.IsType(BindingError{}, err.(BindingErrors).Field("Deadline"))
+}
+
+func TestBind_CanCoerceMappedValues(t *testing.T) {
+	test := assert.New(t)
+
+	var legacy struct {
+		Active   bool   `coerce:"Y=true,N=false"`
+		Features []bool `coerce:"Y=true,N=false"`
+		Level    int    `coerce:"low=1,high=3"`
+		Status   string `coerce:"A=active" default:"A"`
+	}
+
+	err := Bind(&legacy, func(key string) interface{} {
+		switch key {
+		case "Active":
+			return "Y"
+		case "Features":
+			return "Y,N,true"
+		case "Level":
+			return "2"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.True(legacy.Active)
+	test.Equal([]bool{true, false, true}, legacy.Features)
+	test.Equal(2, legacy.Level)
+	test.Equal("active", legacy.Status)
+
+	var broken struct {
+		Active bool `coerce:"Y"`
+	}
+
+	err = Bind(&broken, func(string) interface{} {
+		return "Y"
+	})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBindingErrors_FirstAndLast(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name string `required:"true"`
+		Age  int
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		if key == "Age" {
+			return "XXX"
+		}
+
+		return nil
+	})
+
+	test.Error(err)
+	test.IsType(RequiredError{}, err.(BindingErrors).First())
+	test.IsType(BindingError{}, err.(BindingErrors).Last())
+
+	test.Nil(BindingErrors{}.First())
+	test.Nil(BindingErrors(nil).Last())
+}
+
+type account struct {
+	email string `form:"Email" setter:"SetEmail"`
+	Tags  []string
+	age   int `form:"Age" setter:"SetAge"`
+}
+
+func (account *account) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return fmt.Errorf("invalid email: %q", email)
+	}
+
+	account.email = strings.ToLower(email)
+
+	return nil
+}
+
+func (account *account) SetAge(age int) {
+	account.age = age
+}
+
+func TestBind_CanBindUsingSetters(t *testing.T) {
+	test := assert.New(t)
+
+	var user account
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Email":
+			return "John@Example.com"
+		case "Age":
+			return "42"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal("john@example.com", user.email)
+	test.Equal(42, user.age)
+
+	err = Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Email":
+			return "john"
+		case "Age":
+			return "XXX"
+		default:
+			return nil
+		}
+	})
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.EqualError(
+		errors.Unwrap(err.(BindingErrors).Field("Email")),
+		`invalid email: "john"`,
+	)
+	test.NotNil(err.(BindingErrors).Field("Age"))
+	test.Equal("john@example.com", user.email)
+
+	var broken struct {
+		Email string `setter:"SetEmail"`
+	}
+
+	err = Bind(&broken, func(string) interface{} {
+		return "john@example.com"
+	})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanSkipZeroValues(t *testing.T) {
+	test := assert.New(t)
+
+	user := struct {
+		Name  string `required:"true"`
+		Age   int
+		Email string
+	}{
+		Name:  "John Doe",
+		Age:   30,
+		Email: "john@example.com",
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Name":
+			return ""
+		case "Age":
+			return "0"
+		default:
+			return "johnny@example.com"
+		}
+	}, SkipZero(true))
+
+	test.NoError(err)
+	test.Equal("John Doe", user.Name)
+	test.Equal(30, user.Age)
+	test.Equal("johnny@example.com", user.Email)
+}
+
+func TestBind_CanBindRationalNumbers(t *testing.T) {
+	test := assert.New(t)
+
+	var bet struct {
+		Odds   *big.Rat
+		Stake  *big.Rat
+		Payout *big.Rat
+	}
+
+	err := Bind(&bet, func(key string) interface{} {
+		switch key {
+		case "Odds":
+			return "3/4"
+		case "Stake":
+			return "0.75"
+		default:
+			return "1/0"
+		}
+	})
+
+	test.Equal(big.NewRat(3, 4), bet.Odds)
+	test.Equal(big.NewRat(3, 4), bet.Stake)
+	test.Nil(bet.Payout)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.NotNil(err.(BindingErrors).Field("Payout"))
+}
+
+func TestBind_CanBindRationalNumbersIntoValues(t *testing.T) {
+	test := assert.New(t)
+
+	var bet struct {
+		Odds big.Rat
+	}
+
+	test.NoError(AssertBindable(&bet))
+
+	err := Bind(&bet, func(string) interface{} { return "3/4" })
+
+	test.NoError(err)
+	test.Equal(0, bet.Odds.Cmp(big.NewRat(3, 4)))
+}
+
+type article struct {
+	Slug  string `binding:"@ParseSlug"`
+	Title string
+}
+
+func (article) ParseSlug(value string) (string, error) {
+	slug := strings.ToLower(strings.Replace(value, " ", "-", -1))
+	if strings.Trim(slug, "abcdefghijklmnopqrstuvwxyz0123456789-") != "" {
+		return "", fmt.Errorf("invalid slug: %q", value)
+	}
+
+	return slug, nil
+}
+
+func TestBind_CanUseMethodsAsBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var post article
+
+	err := Bind(&post, func(key string) interface{} {
+		return "Hello World"
+	})
+
+	test.NoError(err)
+	test.Equal("hello-world", post.Slug)
+
+	err = Bind(&post, func(key string) interface{} {
+		return "Hello, World"
+	})
+
+	test.Error(err)
+	test.NotNil(err.(BindingErrors).Field("Slug"))
+
+	var broken struct {
+		Slug string `binding:"@parseSlug"`
+	}
+
+	err = Bind(&broken, func(key string) interface{} {
+		return "hello"
+	})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanFallbackToOtherBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var query struct {
+		Limit  interface{}   `binding:"int||string"`
+		Offset interface{}   `binding:"int||string"`
+		Since  interface{}   `binding:"duration||time:2006-01-02"`
+		Tags   []interface{} `binding:"int:8||string;sep=|"`
+	}
+
+	err := Bind(&query, func(key string) interface{} {
+		switch key {
+		case "Limit":
+			return "10"
+		case "Offset":
+			return "last"
+		case "Since":
+			return "yesterday"
+		default:
+			return "1|1000|x"
+		}
+	})
+
+	test.Equal(10, query.Limit)
+	test.Equal("last", query.Offset)
+	test.Nil(query.Since)
+	test.Equal([]interface{}{int8(1), "1000", "x"}, query.Tags)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.EqualError(
+		errors.Unwrap(err.(BindingErrors).Field("Since")),
+		`parsing time "yesterday" as "2006-01-02": `+
+			`cannot parse "yesterday" as "2006"`,
+	)
+}
+
+func TestAssertBindable_ReportsInconsistentTags(t *testing.T) {
+	test := assert.New(t)
+
+	type Address struct {
+		City string `required:"yes"`
+	}
+
+	type Category struct {
+		Name   string
+		Parent *Category
+	}
+
+	var valid struct {
+		Name    string     `required:"true"`
+		Age     int        `default:"18" oneof:"18 21"`
+		Roles   []string   `oneof:"admin user"`
+		Nick    *string    `oneof:"john jane"`
+		Tags    []string   `default:"a,b"`
+		Login   string     `default:"$Name"`
+		Address *Address   `form:"-"`
+		Items   []Category `minitems:"1" maxitems:"0"`
+		Category
+	}
+
+	test.NoError(AssertBindable(&valid))
+	test.NoError(AssertBindable(valid))
+
+	cases := []interface{}{
+		struct {
+			Age int `binding:"integer"`
+		}{},
+		struct {
+			Age int `default:"eighteen"`
+		}{},
+		struct {
+			Age int `oneof:"18 twenty"`
+		}{},
+		struct {
+			Login string `default:"$Username"`
+		}{},
+		struct {
+			Active bool `coerce:"Y"`
+		}{},
+		struct {
+			Address Address
+		}{},
+		struct {
+			Slug string `binding:"@parseSlug"`
+		}{},
+		struct {
+			Labels map[string]string `maxitems:"10"`
+		}{},
+		struct {
+			Labels map[string]string `binding:"kv" maxitems:"10"`
+		}{},
+		struct {
+			Tags []string `binding:"jsonarray" maxitems:"10"`
+		}{},
+		struct {
+			Tags []string `maxitems:"-1"`
+		}{},
+		struct {
+			Labels map[string]string `oneof:"a b"`
+		}{},
+		struct {
+			StartsAt time.Time `oneof:"now"`
+		}{},
+	}
+
+	for _, output := range cases {
+		err := AssertBindable(output)
+
+		test.Error(err, "%T", output)
+		test.IsType(InvalidBindingError(""), err)
+	}
+
+	test.Error(AssertBindable("string"))
+}
+
+func TestAssertBindable_ChecksBindingTypesAndRequiredLists(t *testing.T) {
+	test := assert.New(t)
+
+	var valid struct {
+		Name  string `required:"required"`
+		Odds  big.Rat
+		Flags uint16 `binding:"flags:a=1|b=2"`
+	}
+
+	test.NoError(AssertBindable(&valid))
+
+	var invalid struct {
+		Age int `binding:"text"`
+	}
+
+	err := AssertBindable(&invalid, WithBinding("text", bindString))
+
+	test.Equal(
+		InvalidBindingError(
+			`binding of Age returns string, which can't be set into int`,
+		),
+		err,
+	)
+}
+
+func TestBind_CanBindSlicesOfTimes(t *testing.T) {
+	test := assert.New(t)
+
+	var schedule struct {
+		Dates     []time.Time     `binding:"time:2006-01-02;sep=,"`
+		Holidays  []time.Time     `binding:"time:02.01.2006;tz=Europe/Berlin;sep=|"`
+		Intervals []time.Duration `binding:"duration:unit=m"`
+	}
+
+	err := Bind(&schedule, func(key string) interface{} {
+		switch key {
+		case "Dates":
+			return "2024-01-01,2024-02-30,2024-03-01,March"
+		case "Holidays":
+			return "25.12.2024|26.12.2024"
+		default:
+			return "5,1h,90"
+		}
+	})
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	test.Nil(schedule.Dates)
+	test.Equal(
+		[]time.Time{
+			time.Date(2024, 12, 25, 0, 0, 0, 0, berlin),
+			time.Date(2024, 12, 26, 0, 0, 0, 0, berlin),
+		},
+		schedule.Holidays,
+	)
+	test.Equal(
+		[]time.Duration{5 * time.Minute, time.Hour, 90 * time.Minute},
+		schedule.Intervals,
+	)
+
+	test.Error(err)
+	test.Equal([]string{"Dates[1]", "Dates[3]"}, err.(BindingErrors).Fields())
+}
+
+func TestBind_CanIgnoreUnknownBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Age      int       `binding:"age"`
+		Email    string    `binding:"mailbox"`
+		Tags     []string  `binding:"tag;sep=|"`
+		Avatar   []byte    `binding:"image"`
+		Birthday time.Time `binding:"date"`
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Age":
+			return "42"
+		case "Email":
+			return "john@example.com"
+		case "Tags":
+			return "a|b"
+		case "Avatar":
+			return nil
+		default:
+			return "2000-01-01T00:00:00Z"
+		}
+	}
+
+	err := Bind(&user, mapper)
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+
+	err = Bind(&user, mapper, IgnoreUnknownBindings(true))
+	test.NoError(err)
+	test.Equal(42, user.Age)
+	test.Equal("john@example.com", user.Email)
+	test.Equal([]string{"a", "b"}, user.Tags)
+	test.Equal(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), user.Birthday)
+}
+
+func TestBind_TrimsAndCoercesNumbersBeforeParsing(t *testing.T) {
+	test := assert.New(t)
+
+	var order struct {
+		Total    float64 `binding:"float:64;locale=en" trim:"$" coerce:"-=0"`
+		Discount float64 `binding:"float:64;locale=en" trim:"$" coerce:"-=0"`
+		Quantity int     `trim:"true"`
+		Codes    []int   `trim:"#"`
+	}
+
+	err := Bind(&order, func(key string) interface{} {
+		switch key {
+		case "Total":
+			return "$ 1,234 "
+		case "Discount":
+			return " - "
+		case "Quantity":
+			return " 42 "
+		default:
+			return "#1, #2"
+		}
+	})
+
+	test.NoError(err)
+	test.Equal(1234.0, order.Total)
+	test.Equal(0.0, order.Discount)
+	test.Equal(42, order.Quantity)
+	test.Equal([]int{1, 2}, order.Codes)
+}
+
+func TestDescribeStruct_DescribesFields(t *testing.T) {
+	test := assert.New(t)
+
+	type Item struct {
+		SKU      string `json:"sku" required:"true"`
+		Quantity int8   `json:"qty" default:"1"`
+	}
+
+	type Order struct {
+		ID     int64  `json:"id" required:"true"`
+		Status string `json:"status" oneof:"new paid" default:"new"`
+		Items  []Item `json:"items"`
+		Notes  string `json:"-"`
+	}
+
+	fields := DescribeStruct(reflect.TypeOf(&Order{}))
+
+	test.Equal(
+		[]FieldBinding{
+			{
+				Name:     "id",
+				Field:    "ID",
+				Type:     reflect.TypeOf(int64(0)),
+				Binding:  "int:64",
+				Required: true,
+				OneOf:    []string{},
+			},
+			{
+				Name:       "status",
+				Field:      "Status",
+				Type:       reflect.TypeOf(""),
+				Binding:    "string",
+				Default:    "new",
+				HasDefault: true,
+				OneOf:      []string{"new", "paid"},
+			},
+			{
+				Name:     "items[].sku",
+				Field:    "SKU",
+				Type:     reflect.TypeOf(""),
+				Binding:  "string",
+				Required: true,
+				OneOf:    []string{},
+			},
+			{
+				Name:       "items[].qty",
+				Field:      "Quantity",
+				Type:       reflect.TypeOf(int8(0)),
+				Binding:    "int:8",
+				Default:    "1",
+				HasDefault: true,
+				OneOf:      []string{},
+			},
+		},
+		fields,
+	)
+
+	fields = DescribeStruct(
+		reflect.TypeOf(Order{}),
+		FieldFilter(func(field reflect.StructField) bool {
+			return field.Name == "Status"
+		}),
+	)
+
+	test.Len(fields, 1)
+	test.Equal("status", fields[0].Name)
+}
+
+type testDescribedAccount struct {
+	Login string
+	Email string   `default:"$Login"`
+	Tags  []string `minitems:"1" maxitems:"5"`
+	Level string   `binding:"level"`
+	age   int      `form:"Age" setter:"SetAge"`
+	Since time.Time
+}
+
+func (account *testDescribedAccount) SetAge(age int16) {
+	account.age = int(age)
+}
+
+func TestDescribeStruct_ResolvesBindingsAndConstraints(t *testing.T) {
+	test := assert.New(t)
+
+	fields := DescribeStruct(
+		reflect.TypeOf(testDescribedAccount{}),
+		TypeBindings{
+			reflect.TypeOf(time.Time{}): func(
+				data interface{},
+				_ string,
+			) (interface{}, error) {
+				return time.Time{}, nil
+			},
+		},
+		IgnoreUnknownBindings(true),
+	)
+
+	test.Len(fields, 6)
+
+	test.Equal("", fields[1].Default)
+	test.Equal("Login", fields[1].DefaultRef)
+	test.True(fields[1].HasDefault)
+
+	test.Equal(1, fields[2].MinItems)
+	test.Equal(5, fields[2].MaxItems)
+
+	test.Equal("string", fields[3].Binding)
+	test.Equal("int:16", fields[4].Binding)
+	test.Equal("", fields[5].Binding)
+}
+
+func TestBind_DistinguishesEmptyAndAbsentStringPointers(t *testing.T) {
+	test := assert.New(t)
+
+	var profile struct {
+		Nickname *string
+		Bio      *string
+		Website  *string
+	}
+
+	err := Bind(&profile, func(key string) interface{} {
+		switch key {
+		case "Bio":
+			return ""
+		case "Website":
+			return "https://example.com"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Nil(profile.Nickname)
+	if test.NotNil(profile.Bio) {
+		test.Equal("", *profile.Bio)
+	}
+	if test.NotNil(profile.Website) {
+		test.Equal("https://example.com", *profile.Website)
+	}
+}
+
+func TestBind_UsesYAMLAndTOMLNames(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Items   []string          `yaml:"items,flow"`
+		Labels  map[string]string `yaml:"labels,omitempty" toml:"label_map"`
+		Port    int               `toml:"port,omitempty"`
+		Host    string            `yaml:",omitempty" toml:"hostname"`
+		Timeout time.Duration     `yaml:",inline"`
+		Secret  string            `yaml:"-" toml:"secret"`
+	}
+
+	keys := []string{}
+
+	err := Bind(&config, func(key string) interface{} {
+		keys = append(keys, key)
+
+		switch key {
+		case "items":
+			return "a,b"
+		case "labels":
+			return map[string]string{"env": "prod"}
+		case "port":
+			return "8080"
+		case "hostname":
+			return "localhost"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal([]string{"items", "labels", "port", "hostname", "Timeout"}, keys)
+	test.Equal([]string{"a", "b"}, config.Items)
+	test.Equal(map[string]string{"env": "prod"}, config.Labels)
+	test.Equal(8080, config.Port)
+	test.Equal("localhost", config.Host)
+}
+
+func TestBindArgs_CanBindFlags(t *testing.T) {
+	test := assert.New(t)
+
+	var options struct {
+		Name    string `form:"name" required:"true"`
+		Port    int    `form:"port"`
+		Verbose bool   `form:"verbose"`
+		Tags    []string
+		Address struct {
+			City string `form:"city"`
+		} `form:"address"`
+	}
+
+	err := BindArgs(&options, []string{
+		"--name", "server",
+		"--port=8080",
+		"--verbose",
+		"--Tags", "a",
+		"--Tags=b",
+		"--address.city", "Berlin",
+		"run",
+		"--port", "9090",
+	})
+
+	test.NoError(err)
+	test.Equal("server", options.Name)
+	test.Equal(8080, options.Port)
+	test.True(options.Verbose)
+	test.Equal([]string{"a", "b"}, options.Tags)
+	test.Equal("Berlin", options.Address.City)
+
+	err = BindArgs(&options, []string{"--debug", "--port", "X", "--verbose=no"})
+
+	test.Error(err)
+	test.Equal(
+		[]string{"debug", "name", "port", "verbose"},
+		err.(BindingErrors).Fields(),
+	)
+
+	err = BindArgs(
+		&options,
+		[]string{"--debug", "--name=x"},
+		IgnoreUnknownArgs(true),
+	)
+	test.NoError(err)
+
+	err = BindArgs(&options, []string{"--name"})
+	test.Error(err)
+	test.Equal([]string{"name"}, err.(BindingErrors).Fields())
+}
+
+func TestBindArgs_RejectsKeyFuncAndNestedSliceFlags(t *testing.T) {
+	test := assert.New(t)
+
+	var options struct {
+		Name  string
+		Items []struct {
+			Name string
+		}
+	}
+
+	err := BindArgs(
+		&options,
+		[]string{"--Name", "server"},
+		KeyFunc(strings.ToUpper),
+	)
+
+	test.IsType(InvalidBindingError(""), err)
+	test.Empty(options.Name)
+
+	err = BindArgs(&options, []string{"--Items[].Name", "a"})
+
+	test.Error(err)
+	test.Equal([]string{"Items[].Name"}, err.(BindingErrors).Fields())
+	test.Nil(options.Items)
+}
+
+type nullable[T any] struct {
+	Value T
+	Valid bool
+}
+
+func bindNullableInt(data interface{}, _ string) (interface{}, error) {
+	if data == "" {
+		return nullable[int]{}, nil
+	}
+
+	value, err := strconv.Atoi(data.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return nullable[int]{Value: value, Valid: true}, nil
+}
+
+func TestBind_CanUseTypeBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Age      nullable[int]
+		Height   nullable[int]
+		Scores   []nullable[int]
+		Weight   *nullable[int]
+		Nickname nullable[string]
+	}
+
+	err := Bind(&user, func(key string) interface{} {
+		switch key {
+		case "Age":
+			return "42"
+		case "Height":
+			return ""
+		case "Scores":
+			return "1,,3"
+		case "Weight":
+			return "80"
+		default:
+			return nil
+		}
+	}, TypeBindings{
+		reflect.TypeOf(nullable[int]{}): bindNullableInt,
+	})
+
+	test.NoError(err)
+	test.Equal(nullable[int]{42, true}, user.Age)
+	test.Equal(nullable[int]{}, user.Height)
+	test.Equal(
+		[]nullable[int]{{1, true}, {}, {3, true}},
+		user.Scores,
+	)
+	test.Equal(&nullable[int]{80, true}, user.Weight)
+	test.Equal(nullable[string]{}, user.Nickname)
+}
+
+func TestBind_CanBindKeyValuePairs(t *testing.T) {
+	test := assert.New(t)
+
+	var pod struct {
+		Labels      map[string]string
+		Limits      map[string]int  `binding:"kv:int;pair=;;kv=:"`
+		Ports       map[int]int64   `binding:"kv"`
+		Annotations map[string]int8 `binding:"kv:int:8"`
+	}
+
+	err := Bind(&pod, func(key string) interface{} {
+		switch key {
+		case "Labels":
+			return map[string]string{"app": "web"}
+		case "Limits":
+			return "cpu:2;memory:512"
+		case "Ports":
+			return "80=8080,443=8443"
+		default:
+			return "replicas=3,weight=1000,broken"
+		}
+	})
+
+	test.Equal(map[string]string{"app": "web"}, pod.Labels)
+	test.Equal(map[string]int{"cpu": 2, "memory": 512}, pod.Limits)
+	test.Equal(map[int]int64{80: 8080, 443: 8443}, pod.Ports)
+	test.Nil(pod.Annotations)
+
+	test.Error(err)
+	test.Equal(
+		[]string{"Annotations[weight]", "Annotations[broken]"},
+		err.(BindingErrors).Fields(),
+	)
+}
+
+func TestBind_SkipsInterfaceFieldsWithoutBinding(t *testing.T) {
+	test := assert.New(t)
+
+	var job struct {
+		Name    string
+		Payload interface{}
+		Err     error
+		Failure error `binding:"error"`
+		Reason  error `binding:"error"`
+	}
+
+	keys := []string{}
+
+	err := Bind(&job, func(key string) interface{} {
+		keys = append(keys, key)
+
+		switch key {
+		case "Failure":
+			return "connection refused"
+		case "Reason":
+			return ""
+		default:
+			return "backup"
+		}
+	})
+
+	test.NoError(err)
+	test.Equal([]string{"Name", "Failure", "Reason"}, keys)
+	test.Equal("backup", job.Name)
+	test.Nil(job.Payload)
+	test.Nil(job.Err)
+	test.EqualError(job.Failure, "connection refused")
+	test.Nil(job.Reason)
+}
+
+func TestBindStrict_EnablesStrictOptions(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name   string
+		ID     []byte `binding:"uuidbytes"`
+		Secret string
+	}
+
+	fieldNameFunc := FieldNameFunc(func(field reflect.StructField) string {
+		if field.Name == "Secret" {
+			return ""
+		}
+
+		return field.Name
+	})
+
+	err := BindStrict(&user, func(key string) interface{} {
+		return nil
+	}, fieldNameFunc)
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+
+	err = BindStrict(&user, func(key string) interface{} {
+		return nil
+	}, fieldNameFunc, StrictNames(false))
+
+	test.Error(err)
+	test.Equal([]string{"ID"}, err.(BindingErrors).Fields())
+
+	var empty struct {
+		secret string
+	}
+
+	err = BindStrict(&empty, func(key string) interface{} {
+		return nil
+	})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanBindSameKeyIntoSeveralFields(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		FirstName string `form:"full_name" binding:"word:0"`
+		LastName  string `form:"full_name" binding:"word:1"`
+		FullName  string `form:"full_name"`
+	}
+
+	calls := 0
+
+	err := Bind(&user, func(key string) interface{} {
+		calls++
+
+		if key == "full_name" {
+			return "John Doe"
+		}
+
+		return nil
+	}, WithBinding("word", func(
+		data interface{},
+		opts string,
+	) (interface{}, error) {
+		index, err := strconv.Atoi(opts)
+		if err != nil {
+			return nil, InvalidBindingError(err.Error())
+		}
+
+		words := strings.Fields(data.(string))
+		if index >= len(words) {
+			return nil, fmt.Errorf("word %d is missing", index)
+		}
+
+		return words[index], nil
+	}))
+
+	test.NoError(err)
+	test.Equal(3, calls)
+	test.Equal("John", user.FirstName)
+	test.Equal("Doe", user.LastName)
+	test.Equal("John Doe", user.FullName)
+}
+
+func TestBindXMLNode_BindsAttributesAndElements(t *testing.T) {
+	test := assert.New(t)
+
+	var node XMLNode
+
+	err := xml.Unmarshal([]byte(`
+		<user id="42" name="attribute">
+			<name>John Doe</name>
+			<email>john@example.com</email>
+			<tag>admin</tag>
+			<tag>staff</tag>
+			<address>
+				<city>Berlin</city>
+			</address>
+		</user>
+	`), &node)
+	test.NoError(err)
+
+	var user struct {
+		ID      int      `xml:"id,attr"`
+		Name    string   `xml:"name"`
+		Email   string   `xml:"email"`
+		Tags    []string `xml:"tag"`
+		Address struct {
+			City string `xml:"city"`
+		} `xml:"address"`
+		Phone string `xml:"phone"`
+	}
+
+	err = BindXMLNode(&user, node)
+
+	test.NoError(err)
+	test.Equal(42, user.ID)
+	test.Equal("attribute", user.Name)
+	test.Equal("john@example.com", user.Email)
+	test.Equal([]string{"admin", "staff"}, user.Tags)
+	test.Equal("Berlin", user.Address.City)
+	test.Empty(user.Phone)
+}
+
+func TestBindCSVRecord_BindsByColumnIndex(t *testing.T) {
+	test := assert.New(t)
+
+	type Transaction struct {
+		Date    time.Time `csv:"0" binding:"time:02.01.2006"`
+		Amount  float64   `csv:"2" binding:"float:64;locale=de"`
+		Payee   string    `csv:"1" required:"true"`
+		Comment *string   `csv:"3"`
+		Balance float64
+	}
+
+	var transaction Transaction
+
+	err := BindCSVRecord(
+		&transaction,
+		[]string{"15.03.2024", "ACME Corp", "-1.234,50", ""},
+	)
+
+	test.NoError(err)
+	test.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), transaction.Date)
+	test.Equal(-1234.5, transaction.Amount)
+	test.Equal("ACME Corp", transaction.Payee)
+	test.Equal("", *transaction.Comment)
+
+	transaction = Transaction{}
+
+	err = BindCSVRecord(
+		&transaction,
+		[]string{"15.03.2024", "", "0", ""},
+		EmptyAsAbsent(true),
+	)
+
+	test.Error(err)
+	test.Equal([]string{"1"}, err.(BindingErrors).Fields())
+	test.Nil(transaction.Comment)
+
+	err = BindCSVRecord(&transaction, []string{"15.03.2024", "ACME Corp"})
+
+	test.Error(err)
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanZeroFieldsOnError(t *testing.T) {
+	test := assert.New(t)
+
+	type User struct {
+		Name    string
+		Age     int
+		Color   string `oneof:"red green"`
+		Tags    []int
+		Limits  map[string]int
+		Created time.Time `binding:"datetime:date=date,time=time"`
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "John Doe"
+		case "Color":
+			return "purple"
+		case "Age", "Tags":
+			return "X"
+		case "Limits":
+			return map[string]string{"cpu": "2", "memory": "X"}
+		case "date":
+			return "2024-01-01"
+		default:
+			return nil
+		}
+	}
+
+	user := User{
+		Age:     30,
+		Color:   "red",
+		Tags:    []int{1},
+		Created: time.Now(),
+	}
+
+	err := Bind(&user, mapper)
+	test.Error(err)
+	test.Equal(30, user.Age)
+	test.Equal("red", user.Color)
+	test.Equal([]int{1}, user.Tags)
+	test.Equal(map[string]int{"cpu": 2}, user.Limits)
+	test.False(user.Created.IsZero())
+
+	err = Bind(&user, mapper, ZeroOnError(true))
+	test.Error(err)
+	test.Equal("John Doe", user.Name)
+	test.Zero(user.Age)
+	test.Empty(user.Color)
+	test.Nil(user.Tags)
+	test.Nil(user.Limits)
+	test.True(user.Created.IsZero())
+}
+
+func TestBind_LimitsNumberOfSliceItems(t *testing.T) {
+	test := assert.New(t)
+
+	var post struct {
+		Tags       []string `maxitems:"3"`
+		Categories []int    `minitems:"1" maxitems:"2"`
+		Authors    []string `minitems:"1"`
+		Links      []string `minitems:"1"`
+	}
+
+	err := Bind(&post, func(key string) interface{} {
+		switch key {
+		case "Tags":
+			return "a,b,c,d"
+		case "Categories":
+			return []string{"1", "2"}
+		case "Authors":
+			return ""
+		default:
+			return "https://example.com"
+		}
+	})
+
+	test.Nil(post.Tags)
+	test.Equal([]int{1, 2}, post.Categories)
+	test.Equal([]string{"https://example.com"}, post.Links)
+
+	test.Error(err)
+	test.Len(err, 2)
+
+	var lengthError LengthError
+
+	test.True(errors.As(err.(BindingErrors).Field("Tags"), &lengthError))
+	test.Equal(4, lengthError.Actual())
+	test.Equal(3, lengthError.Max())
+	test.EqualError(
+		lengthError,
+		"Tags — 4 items given, but at most 3 allowed",
+	)
+
+	test.EqualError(
+		err.(BindingErrors).Field("Authors"),
+		"Authors — 0 items given, but at least 1 required",
+	)
+}
+
+func TestBind_CanBindIntoTemplateSafeStrings(t *testing.T) {
+	test := assert.New(t)
+
+	var page struct {
+		Body   template.HTML
+		Script template.JS
+		Style  template.CSS
+		Link   *template.URL
+	}
+
+	err := Bind(&page, func(key string) interface{} {
+		switch key {
+		case "Body":
+			return "<b>hello</b>"
+		case "Script":
+			return "alert(1)"
+		case "Style":
+			return "color: red"
+		case "Link":
+			return "https://example.com"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal(template.HTML("<b>hello</b>"), page.Body)
+	test.Equal(template.JS("alert(1)"), page.Script)
+	test.Equal(template.CSS("color: red"), page.Style)
+	test.Equal(template.URL("https://example.com"), *page.Link)
+}
+
+func TestBind_CanUseRequiredFunc(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name  string `validate:"required"`
+		Email string `required:"true"`
+		Phone string
+	}
+
+	err := Bind(
+		&user,
+		func(key string) interface{} { return nil },
+		RequiredFunc(func(field reflect.StructField) bool {
+			return strings.Contains(field.Tag.Get("validate"), "required")
+		}),
+	)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.EqualError(
+		err.(BindingErrors).Field("Name"),
+		"Name — field required but not specified",
+	)
+}
+
+func TestBind_CopiesValuesIntoPassthroughMaps(t *testing.T) {
+	test := assert.New(t)
+
+	var event struct {
+		Name     string
+		Metadata map[string]interface{}
+		Labels   map[string]interface{} `binding:"int"`
+	}
+
+	err := Bind(&event, func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "deploy"
+		case "Metadata":
+			return map[string]interface{}{
+				"attempt": float64(3),
+				"retry":   true,
+				"owner":   map[string]interface{}{"team": "infra"},
+				"tags":    []interface{}{"a", "b"},
+			}
+		case "Labels":
+			return map[string]string{"priority": "1"}
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal("deploy", event.Name)
+	test.Equal(
+		map[string]interface{}{
+			"attempt": float64(3),
+			"retry":   true,
+			"owner":   map[string]interface{}{"team": "infra"},
+			"tags":    []interface{}{"a", "b"},
+		},
+		event.Metadata,
+	)
+	test.Equal(map[string]interface{}{"priority": 1}, event.Labels)
+
+	err = Bind(&event, func(key string) interface{} {
+		if key == "Metadata" {
+			return "attempt=3"
+		}
+
+		return nil
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanBindPhoneNumbers(t *testing.T) {
+	test := assert.New(t)
+
+	var contact struct {
+		Phone  string `binding:"phone"`
+		Mobile string `binding:"phone:10,11"`
+		Fax    string `binding:"phone"`
+		Office string `binding:"phone"`
+	}
+
+	err := Bind(&contact, func(key string) interface{} {
+		switch key {
+		case "Phone":
+			return "+1 (555) 123-45.67"
+		case "Mobile":
+			return "555 1234"
+		case "Fax":
+			return "555-CALL-NOW"
+		case "Office":
+			return "555 123 4567"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal("+15551234567", contact.Phone)
+	test.Equal("5551234567", contact.Office)
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.EqualError(
+		err.(BindingErrors).Field("Mobile"),
+		"Mobile — phone number should have from 10 to 11 digits, but 7 given",
+	)
+	test.EqualError(
+		err.(BindingErrors).Field("Fax"),
+		`Fax — invalid phone number: "555-CALL-NOW"`,
+	)
+}
+
+func TestBind_CanNormalizePhoneNumbers(t *testing.T) {
+	test := assert.New(t)
+
+	var contact struct {
+		Phone []string `binding:"phone"`
+	}
+
+	err := Bind(
+		&contact,
+		func(key string) interface{} {
+			return []string{"(555) 123-4567", "+44 20 7946 0958"}
+		},
+		PhoneNormalizer(func(phone string) (string, error) {
+			if strings.HasPrefix(phone, "+") {
+				return phone, nil
+			}
+
+			return "+1" + phone, nil
+		}),
+	)
+
+	test.NoError(err)
+	test.Equal([]string{"+15551234567", "+442079460958"}, contact.Phone)
+}
+
+func TestBind_PhoneBindingTakesPrecedenceOverNormalizer(t *testing.T) {
+	test := assert.New(t)
+
+	var (
+		normalizer = PhoneNormalizer(func(phone string) (string, error) {
+			return "normalized", nil
+		})
+		bindings = WithBinding(
+			"phone",
+			func(data interface{}, _ string) (interface{}, error) {
+				return "custom", nil
+			},
+		)
+		mapper = func(key string) interface{} { return "555-1234" }
+	)
+
+	var contact struct {
+		Phone string `binding:"phone"`
+	}
+
+	err := Bind(&contact, mapper, normalizer, bindings)
+
+	test.NoError(err)
+	test.Equal("custom", contact.Phone)
+
+	err = Bind(&contact, mapper, bindings, normalizer)
+
+	test.NoError(err)
+	test.Equal("custom", contact.Phone)
+}
+
+type testSentinelDate struct {
+	time.Time
+}
+
+func (date testSentinelDate) IsEmpty() bool {
+	return date.Year() == 1970
+}
+
+func TestBind_SkipZeroUsesEmptier(t *testing.T) {
+	test := assert.New(t)
+
+	var (
+		expires = testSentinelDate{time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)}
+		started = testSentinelDate{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	)
+
+	subscription := struct {
+		Expires testSentinelDate  `binding:"time:2006-01-02"`
+		Started *testSentinelDate `binding:"time:2006-01-02"`
+	}{
+		Expires: expires,
+		Started: &started,
+	}
+
+	err := Bind(
+		&subscription,
+		func(key string) interface{} {
+			return "1970-01-01"
+		},
+		Bindings{
+			"time": func(data interface{}, opts string) (interface{}, error) {
+				value, err := bindTime(data, opts)
+				if err != nil {
+					return nil, err
+				}
+
+				return testSentinelDate{value.(time.Time)}, nil
+			},
+		},
+		SkipZero(true),
+	)
+
+	test.NoError(err)
+	test.Equal(expires, subscription.Expires)
+	test.Equal(started, *subscription.Started)
+}
+
+func TestUnbind_OmitsEmptiers(t *testing.T) {
+	test := assert.New(t)
+
+	values, err := Unbind(struct {
+		Expires testSentinelDate `json:"expires,omitempty"`
+		Started testSentinelDate `json:"started,omitempty"`
+	}{
+		Expires: testSentinelDate{time.Unix(0, 0).UTC()},
+		Started: testSentinelDate{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	test.NoError(err)
+	test.Equal(
+		map[string]string{"started": "2020-01-01 00:00:00 +0000 UTC"},
+		values,
+	)
+}
+
+func TestUnbind_RoundTripsThroughBind(t *testing.T) {
+	test := assert.New(t)
+
+	type Item struct {
+		Name  string
+		Count int
+	}
+
+	type Order struct {
+		ID      int64
+		Tags    []string
+		Address struct {
+			City string
+			Zip  *int
+		}
+		Items []*Item
+	}
+
+	zip := 10001
+
+	input := Order{ID: 42, Tags: []string{"a", "b"}}
+	input.Address.City = "NYC"
+	input.Address.Zip = &zip
+	input.Items = []*Item{{"apple", 2}, {"pear", 3}}
+
+	options := []interface{}{
+		Prefix("order_"),
+		KeyFunc(strings.ToLower),
+	}
+
+	values, err := Unbind(input, options...)
+
+	test.NoError(err)
+	test.Equal(
+		map[string]string{
+			"order_id":             "42",
+			"order_tags":           "a,b",
+			"order_address.city":   "NYC",
+			"order_address.zip":    "10001",
+			"order_items[0].name":  "apple",
+			"order_items[0].count": "2",
+			"order_items[1].name":  "pear",
+			"order_items[1].count": "3",
+		},
+		values,
+	)
+
+	var output Order
+
+	err = Bind(&output, func(key string) interface{} {
+		if value, ok := values[key]; ok {
+			return value
+		}
+
+		return nil
+	}, options...)
+
+	test.NoError(err)
+	test.Equal(input, output)
+}
+
+func TestBind_CanReadValuesFromMap(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name    string
+		Age     int
+		Country string `default:"US"`
+		City    string
+	}
+
+	err := Bind(
+		&user,
+		nil,
+		Values{"Name": "John", "Age": "27"},
+	)
+
+	test.NoError(err)
+	test.Equal("John", user.Name)
+	test.Equal(27, user.Age)
+	test.Equal("US", user.Country)
+
+	err = Bind(
+		&user,
+		func(key string) interface{} {
+			if key == "user_city" || key == "user_name" {
+				return "fallback"
+			}
+
+			return nil
+		},
+		Values{"user_name": "Jane"},
+		Prefix("user_"),
+		FieldNameFunc(func(field reflect.StructField) string {
+			return strings.ToLower(field.Name)
+		}),
+	)
+
+	test.NoError(err)
+	test.Equal("Jane", user.Name)
+	test.Equal("fallback", user.City)
+}
+
+func benchmarkBindWideStruct(
+	b *testing.B,
+	mapper MapFunc,
+	options ...interface{},
+) {
+	fields := make([]reflect.StructField, 200)
+	for i := range fields {
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: reflect.TypeOf(0),
+		}
+	}
+
+	output := reflect.New(reflect.StructOf(fields)).Interface()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		err := Bind(output, mapper, options...)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func getWideStructValues() Values {
+	values := Values{}
+	for i := 0; i < 200; i++ {
+		values[fmt.Sprintf("Field%d", i)] = strconv.Itoa(i)
+	}
+
+	return values
+}
+
+func BenchmarkBind_WideStructMapper(b *testing.B) {
+	values := getWideStructValues()
+
+	benchmarkBindWideStruct(b, func(key string) interface{} {
+		return values[key]
+	})
+}
+
+func BenchmarkBind_WideStructValues(b *testing.B) {
+	benchmarkBindWideStruct(b, nil, getWideStructValues())
+}
+
+type testPayment interface {
+	Amount() int
+}
+
+type testCardPayment struct {
+	Number string `required:"true"`
+	Total  int
+}
+
+func (payment *testCardPayment) Amount() int {
+	return payment.Total
+}
+
+type testCashPayment struct {
+	Total int
+}
+
+func (payment *testCashPayment) Amount() int {
+	return payment.Total
+}
+
+func TestBind_CanBindPolymorphicFields(t *testing.T) {
+	test := assert.New(t)
+
+	type order struct {
+		Payment testPayment `binding:"polymorphic:Method"`
+		Method  string
+	}
+
+	types := PolymorphicTypes{
+		"card": func() interface{} { return &testCardPayment{} },
+		"cash": func() interface{} { return &testCashPayment{} },
+	}
+
+	var card order
+
+	err := Bind(&card, func(key string) interface{} {
+		switch key {
+		case "Method":
+			return "card"
+		case "Payment.Number":
+			return "4242"
+		case "Payment.Total":
+			return "100"
+		default:
+			return nil
+		}
+	}, types)
+
+	test.NoError(err)
+	test.Equal(&testCardPayment{Number: "4242", Total: 100}, card.Payment)
+
+	var cash order
+
+	err = Bind(&cash, func(key string) interface{} {
+		switch key {
+		case "Method":
+			return "cash"
+		case "Payment":
+			return map[string]interface{}{"Total": "50"}
+		default:
+			return nil
+		}
+	}, types, NestedMaps(true))
+
+	test.NoError(err)
+	test.Equal(&testCashPayment{Total: 50}, cash.Payment)
+
+	var invalid order
+
+	err = Bind(&invalid, func(key string) interface{} {
+		switch key {
+		case "Method":
+			return "crypto"
+		default:
+			return nil
+		}
+	}, types)
+
+	test.Error(err)
+	test.Nil(invalid.Payment)
+	test.EqualError(
+		err.(BindingErrors).Field("Payment"),
+		`Payment — unknown type "crypto"`,
+	)
+
+	err = Bind(&invalid, func(key string) interface{} {
+		if key == "Method" {
+			return "card"
+		}
+
+		return nil
+	}, types)
+
+	test.Error(err)
+	test.EqualError(
+		err.(BindingErrors).Field("Payment.Number"),
+		"Payment.Number — field required but not specified",
+	)
+}
+
+func TestBind_ReportsPolymorphicMisconfiguration(t *testing.T) {
+	test := assert.New(t)
+
+	var order struct {
+		Payment testPayment `binding:"polymorphic:Kind"`
+	}
+
+	err := Bind(&order, func(key string) interface{} {
+		return nil
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+	test.IsType(InvalidBindingError(""), AssertBindable(&order))
+}
+
+func TestBindWithReport_ReturnsWarnings(t *testing.T) {
+	test := assert.New(t)
+
+	var product struct {
+		Price  float64   `binding:"float:64;locale=de"`
+		Weight float64   `binding:"float:64;locale=de"`
+		Sizes  []float64 `binding:"float:64;locale=de;sep=|"`
+		Stock  int       `binding:"integer"`
+	}
+
+	report, err := BindWithReport(
+		&product,
+		func(key string) interface{} {
+			switch key {
+			case "Price":
+				return "1.234,5"
+			case "Weight":
+				return "12"
+			case "Sizes":
+				return "1,5|2"
+			case "Stock":
+				return "10"
+			default:
+				return nil
+			}
+		},
+		Aliases{"integer": "int"},
+	)
+
+	test.NoError(err)
+	test.Equal(1234.5, product.Price)
+	test.Equal(10, product.Stock)
+	test.Equal(
+		[]Warning{
+			{"Price", `"1.234,5" is normalized to "1234.5" using locale "de"`},
+			{"Sizes", `"1,5" is normalized to "1.5" using locale "de"`},
+			{"Stock", `binding "integer" is an alias of "int"`},
+		},
+		report.Warnings,
+	)
+	test.Equal(
+		`Stock — binding "integer" is an alias of "int"`,
+		report.Warnings[2].String(),
+	)
+}
+
+func TestBind_PassesDottedNamesVerbatim(t *testing.T) {
+	test := assert.New(t)
+
+	var (
+		keys    []string
+		profile struct {
+			Zip     int    `form:"address.zip"`
+			City    string `json:"address.city,omitempty"`
+			Contact struct {
+				Email string `form:"primary.email"`
+			}
+		}
+	)
+
+	mapper := func(key string) interface{} {
+		keys = append(keys, key)
+
+		switch key {
+		case "address.zip":
+			return "10001"
+		case "address.city":
+			return "NYC"
+		case "Contact.primary.email":
+			return "john@example.com"
+		default:
+			return nil
+		}
+	}
+
+	err := Bind(&profile, mapper)
+
+	test.NoError(err)
+	test.Equal(10001, profile.Zip)
+	test.Equal("NYC", profile.City)
+	test.Equal("john@example.com", profile.Contact.Email)
+	test.Equal(
+		[]string{"address.zip", "address.city", "Contact.primary.email"},
+		keys,
+	)
+
+	keys = nil
+
+	err = Bind(&profile, func(key string) interface{} {
+		keys = append(keys, key)
+
+		if key == "Contact" {
+			return map[string]interface{}{"primary.email": "jane@example.com"}
+		}
+
+		return nil
+	}, NestedMaps(true))
+
+	test.NoError(err)
+	test.Equal("jane@example.com", profile.Contact.Email)
+	test.Equal([]string{"address.zip", "address.city", "Contact"}, keys)
+}
+
+func TestBind_ReportsISODurationsOutOfRange(t *testing.T) {
+	test := assert.New(t)
+
+	duration, err := parseISODuration("PT9223372036S", false)
+
+	test.NoError(err)
+	test.Equal(9223372036*time.Second, duration)
+
+	for _, value := range []string{
+		"PT9223372036.854775807S",
+		"PT9223372036.854775808S",
+		"-PT9223372036.854775808S",
+		"PT2562047.8H",
+	} {
+		_, err := parseISODuration(value, false)
+
+		test.EqualError(
+			err,
+			fmt.Sprintf("ISO 8601 duration is out of range: %q", value),
+		)
+	}
+}
+
+func TestBind_CanBindISODurations(t *testing.T) {
+	test := assert.New(t)
+
+	var task struct {
+		Timeout  time.Duration   `binding:"isoduration"`
+		Interval time.Duration   `binding:"isoduration"`
+		Retries  []time.Duration `binding:"isoduration"`
+		Period   time.Duration   `binding:"isoduration:approximate"`
+		Lifetime time.Duration   `binding:"isoduration"`
+		Delay    time.Duration   `binding:"isoduration"`
+		Backoff  time.Duration   `binding:"isoduration"`
+	}
+
+	err := Bind(&task, func(key string) interface{} {
+		switch key {
+		case "Timeout":
+			return "P1DT2H30M"
+		case "Interval":
+			return "-PT0,5S"
+		case "Retries":
+			return "PT1S,PT1M,P1W"
+		case "Period":
+			return "P1Y2M"
+		case "Lifetime":
+			return "P1Y"
+		case "Delay":
+			return "PT1H2D"
+		case "Backoff":
+			return "P1DT"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(26*time.Hour+30*time.Minute, task.Timeout)
+	test.Equal(-500*time.Millisecond, task.Interval)
+	test.Equal(
+		[]time.Duration{time.Second, time.Minute, 7 * 24 * time.Hour},
+		task.Retries,
+	)
+	test.Equal(425*24*time.Hour, task.Period)
+
+	test.Error(err)
+	test.Len(err, 3)
+	test.EqualError(
+		err.(BindingErrors).Field("Lifetime"),
+		`Lifetime — years and months are not allowed in ISO 8601 `+
+			`duration: "P1Y"`,
+	)
+	test.EqualError(
+		err.(BindingErrors).Field("Delay"),
+		`Delay — invalid ISO 8601 duration: "PT1H2D"`,
+	)
+	test.EqualError(
+		err.(BindingErrors).Field("Backoff"),
+		`Backoff — invalid ISO 8601 duration: "P1DT"`,
+	)
+}
+
+func TestBind_CanBindAtomicFields(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Limit   atomic.Int64
+		Enabled *atomic.Bool
+		Workers atomic.Int32 `binding:"int:32"`
+		Name    atomic.Value
+		Timeout atomic.Value `binding:"duration"`
+		Retries atomic.Int64
+	}
+
+	err := Bind(&config, func(key string) interface{} {
+		switch key {
+		case "Limit":
+			return "100"
+		case "Enabled":
+			return "true"
+		case "Workers":
+			return "4"
+		case "Name":
+			return "api"
+		case "Timeout":
+			return "5s"
+		case "Retries":
+			return "many"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(int64(100), config.Limit.Load())
+	test.True(config.Enabled.Load())
+	test.Equal(int32(4), config.Workers.Load())
+	test.Equal("api", config.Name.Load())
+	test.Equal(5*time.Second, config.Timeout.Load())
+	test.Equal(int64(0), config.Retries.Load())
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.Error(err.(BindingErrors).Field("Retries"))
+}
+
+func TestBind_CanUseRequiredTagKey(t *testing.T) {
+	test := assert.New(t)
+
+	var user struct {
+		Name  string `validate:"required"`
+		Email string `validate:"email, required"`
+		Phone string `validate:"false"`
+		Age   int    `required:"true"`
+	}
+
+	err := Bind(
+		&user,
+		func(key string) interface{} { return nil },
+		RequiredTagKey("validate"),
+	)
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.Error(err.(BindingErrors).Field("Name"))
+	test.Error(err.(BindingErrors).Field("Email"))
+
+	test.NoError(AssertBindable(&user, RequiredTagKey("validate")))
+	test.Equal(
+		"validate",
+		NewDecoder(RequiredTagKey("validate")).Config().RequiredTagKey,
+	)
+}
+
+func TestBind_CanBindEmbeddedJSON(t *testing.T) {
+	test := assert.New(t)
+
+	type filter struct {
+		Field string `json:"field"`
+		Value int    `json:"value"`
+	}
+
+	var search struct {
+		Filter  filter         `binding:"json"`
+		Options *filter        `binding:"json"`
+		Sort    []string       `binding:"json"`
+		Extra   map[string]int `binding:"json"`
+		Broken  filter         `binding:"json"`
+	}
+
+	err := Bind(&search, func(key string) interface{} {
+		switch key {
+		case "Filter":
+			return `{"field": "age", "value": 18}`
+		case "Options":
+			return `{"field": "name"}`
+		case "Sort":
+			return `["name", "age"]`
+		case "Broken":
+			return `{"value": "many"}`
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(filter{Field: "age", Value: 18}, search.Filter)
+	test.Equal(&filter{Field: "name"}, search.Options)
+	test.Equal([]string{"name", "age"}, search.Sort)
+	test.Nil(search.Extra)
+
+	test.Error(err)
+	test.Len(err, 1)
+
+	var typeError *json.UnmarshalTypeError
+
+	test.True(errors.As(err.(BindingErrors).Field("Broken"), &typeError))
+}
+
+func TestBind_CanBindEmailAddresses(t *testing.T) {
+	test := assert.New(t)
+
+	var message struct {
+		From    mail.Address
+		ReplyTo *mail.Address
+		To      []mail.Address
+		Sender  string `binding:"email:strict,address"`
+		Cc      string `binding:"email:strict"`
+		Bcc     mail.Address
+	}
+
+	err := Bind(&message, func(key string) interface{} {
+		switch key {
+		case "From":
+			return "John Doe <john@example.com>"
+		case "ReplyTo":
+			return "reply@example.com"
+		case "To":
+			return []string{"a@example.com", "B <b@example.com>"}
+		case "Sender":
+			return "sender@example.com"
+		case "Cc":
+			return "Jane <jane@example.com>"
+		case "Bcc":
+			return "not an email"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(
+		mail.Address{Name: "John Doe", Address: "john@example.com"},
+		message.From,
+	)
+	test.Equal(&mail.Address{Address: "reply@example.com"}, message.ReplyTo)
+	test.Equal(
+		[]mail.Address{
+			{Address: "a@example.com"},
+			{Name: "B", Address: "b@example.com"},
+		},
+		message.To,
+	)
+	test.Equal("sender@example.com", message.Sender)
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.EqualError(
+		err.(BindingErrors).Field("Cc"),
+		`Cc — email address has display name: "Jane <jane@example.com>"`,
+	)
+	test.EqualError(
+		err.(BindingErrors).Field("Bcc"),
+		`Bcc — invalid email address: "not an email"`,
+	)
+}
+
+func TestBind_UsesFromAndToTags(t *testing.T) {
+	test := assert.New(t)
+
+	type user struct {
+		Name     string `from:"user_name" to:"userName"`
+		Email    string `json:"email" to:"mail"`
+		Age      int    `json:"age" from:"years"`
+		Password string `from:"password" to:"-"`
+		ID       int    `from:"-" json:"id"`
+	}
+
+	var keys []string
+
+	var output user
+
+	err := Bind(&output, func(key string) interface{} {
+		keys = append(keys, key)
+
+		switch key {
+		case "user_name":
+			return "John"
+		case "email":
+			return "john@example.com"
+		case "years":
+			return "27"
+		case "password":
+			return "secret"
+		default:
+			return "1"
+		}
+	}, StrictNames(true))
+
+	test.NoError(err)
+	test.Equal([]string{"user_name", "email", "years", "password"}, keys)
+	test.Equal(
+		user{
+			Name:     "John",
+			Email:    "john@example.com",
+			Age:      27,
+			Password: "secret",
+		},
+		output,
+	)
+
+	output.ID = 1
+
+	values, err := Unbind(output)
+
+	test.NoError(err)
+	test.Equal(
+		map[string]string{
+			"userName": "John",
+			"mail":     "john@example.com",
+			"age":      "27",
+			"id":       "1",
+		},
+		values,
+	)
+}
+
+func TestBind_ReportsInvalidIntOptions(t *testing.T) {
+	test := assert.New(t)
+
+	mapper := func(key string) interface{} {
+		return "10"
+	}
+
+	var invalidBase struct {
+		Value int `binding:"int:0,99"`
+	}
+
+	err := Bind(&invalidBase, mapper)
+	test.IsType(InvalidBindingError(""), err)
+	test.EqualError(err, "base should be 0 or from 2 to 36, but 99 given")
+
+	var invalidBits struct {
+		Value int `binding:"int:12"`
+	}
+
+	err = Bind(&invalidBits, mapper)
+	test.IsType(InvalidBindingError(""), err)
+	test.EqualError(
+		err,
+		"bits should be one of 0, 8, 16, 32 or 64, but 12 given",
+	)
+
+	var invalidUint struct {
+		Value uint `binding:"uint:0,1"`
+	}
+
+	err = Bind(&invalidUint, mapper, ExtendedBindings())
+	test.IsType(InvalidBindingError(""), err)
+
+	var valid struct {
+		Auto   int   `binding:"int:0,0"`
+		Binary int   `binding:"int:0,2"`
+		Max    int64 `binding:"int:64,36"`
+	}
+
+	err = Bind(&valid, mapper)
+	test.NoError(err)
+	test.Equal(10, valid.Auto)
+	test.Equal(2, valid.Binary)
+	test.Equal(int64(36), valid.Max)
+}
+
+func TestBind_ChecksComparisons(t *testing.T) {
+	test := assert.New(t)
+
+	var booking struct {
+		Start    time.Time `binding:"time:2006-01-02"`
+		End      time.Time `binding:"time:2006-01-02"`
+		Guests   int
+		Rooms    int `default:"$Guests"`
+		Password string
+		Confirm  string
+		Period   struct {
+			Min float64
+			Max float64
+		}
+	}
+
+	err := Bind(
+		&booking,
+		func(key string) interface{} {
+			switch key {
+			case "Start":
+				return "2024-05-10"
+			case "End":
+				return "2024-05-01"
+			case "Guests":
+				return "2"
+			case "Password":
+				return "secret"
+			case "Confirm":
+				return "secret"
+			case "Period.Min":
+				return "1.5"
+			case "Period.Max":
+				return "0.5"
+			default:
+				return nil
+			}
+		},
+		Compare{
+			{"Start", "<", "End"},
+			{"Password", "==", "Confirm"},
+			{"Rooms", "<=", "Guests"},
+			{"Period.Min", "<=", "Period.Max"},
+		},
+	)
+
+	test.Error(err)
+	test.Len(err, 2)
+
+	var comparisonError ComparisonError
+
+	test.True(
+		errors.As(err.(BindingErrors).Field("Start"), &comparisonError),
+	)
+	test.Equal("<", comparisonError.Operator())
+	test.Equal("End", comparisonError.Right())
+	test.EqualError(comparisonError, "Start — value should be < End")
+	test.EqualError(
+		err.(BindingErrors).Field("Period.Min"),
+		"Period.Min — value should be <= Period.Max",
+	)
+
+	err = Bind(
+		&booking,
+		func(key string) interface{} {
+			switch key {
+			case "Start":
+				return "2024-05-10"
+			case "Password":
+				return "secret"
+			default:
+				return nil
+			}
+		},
+		Compare{{"Start", "<", "Password"}},
+	)
+
+	test.EqualError(
+		err,
+		"Start — can't compare with Password using <: "+
+			"time.Time and string are not comparable",
+	)
+	test.Len(err, 1)
+}
+
+func TestBind_SkipsComparisonsOfNilPointers(t *testing.T) {
+	test := assert.New(t)
+
+	var limits struct {
+		Min *int
+		Max *int
+	}
+
+	err := Bind(
+		&limits,
+		func(key string) interface{} {
+			if key == "Max" {
+				return "10"
+			}
+
+			return nil
+		},
+		Compare{{"Min", "<=", "Max"}},
+	)
+
+	test.NoError(err)
+	test.Equal(10, *limits.Max)
+}
+
+func TestAssertBindable_ChecksComparisons(t *testing.T) {
+	test := assert.New(t)
+
+	type Period struct {
+		Start time.Time
+		End   time.Time
+	}
+
+	var booking struct {
+		Name   string
+		Guests *int
+		Rooms  int
+		Stay   Period
+		Refund Period
+	}
+
+	test.NoError(AssertBindable(&booking, Compare{
+		{"Rooms", "<=", "Guests"},
+		{"Stay.Start", "<", "Stay.End"},
+		{"Refund.End", "<=", "Stay.Start"},
+	}))
+
+	test.EqualError(
+		AssertBindable(&booking, Compare{{"Rooms", "<=", "Guest"}}),
+		"comparison Rooms <= Guest refers to unknown field Guest",
+	)
+	test.EqualError(
+		AssertBindable(&booking, Compare{{"Name", "<", "Rooms"}}),
+		"can't compare Name < Rooms: string and int are not comparable",
+	)
+	test.EqualError(
+		AssertBindable(&booking, Compare{{"Rooms", "=<", "Guests"}}),
+		`can't compare Rooms =< Guests: unknown operator "=<"`,
+	)
+}
+
+func TestBind_CanBindMapsOfStructsWithIntKeys(t *testing.T) {
+	test := assert.New(t)
+
+	type Endpoint struct {
+		URL     string `required:"true"`
+		Timeout time.Duration
+	}
+
+	mapper := func(key string) interface{} {
+		return map[string]interface{}{
+			"1": map[string]interface{}{"URL": "http://a", "Timeout": "1s"},
+			"2": map[string]interface{}{"URL": "http://b", "Timeout": "x"},
+			"x": map[string]interface{}{"URL": "http://c"},
+		}
+	}
+
+	var registry struct {
+		Endpoints map[int]Endpoint
+	}
+
+	err := Bind(&registry, mapper)
+
+	test.Equal(
+		map[int]Endpoint{1: {URL: "http://a", Timeout: time.Second}},
+		registry.Endpoints,
+	)
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.Error(err.(BindingErrors).Field("Endpoints[2].Timeout"))
+	test.Error(err.(BindingErrors).Field("Endpoints[x]"))
+
+	registry.Endpoints = map[int]Endpoint{3: {URL: "http://d"}}
+
+	err = Bind(&registry, mapper, TransactionalMaps(true))
+
+	test.Error(err)
+	test.Equal(map[int]Endpoint{3: {URL: "http://d"}}, registry.Endpoints)
+
+	err = Bind(&registry, mapper, TransactionalMaps(true), ZeroOnError(true))
+
+	test.Error(err)
+	test.Nil(registry.Endpoints)
+}
+
+func TestBind_BindsMapsOfStructsInKeyOrder(t *testing.T) {
+	test := assert.New(t)
+
+	type Endpoint struct {
+		Timeout time.Duration
+	}
+
+	mapper := func(key string) interface{} {
+		return map[string]interface{}{
+			"10": map[string]interface{}{"Timeout": "x"},
+			"9":  map[string]interface{}{"Timeout": "y"},
+			"1":  map[string]interface{}{"Timeout": "1s"},
+		}
+	}
+
+	var registry struct {
+		Endpoints map[int]Endpoint
+	}
+
+	err := Bind(&registry, mapper)
+
+	test.Equal(
+		[]string{"Endpoints[9].Timeout", "Endpoints[10].Timeout"},
+		err.(BindingErrors).Fields(),
+	)
+
+	registry.Endpoints = map[int]Endpoint{3: {}}
+
+	err = Bind(&registry, mapper, FailFast(true), TransactionalMaps(true))
+
+	test.Equal([]string{"Endpoints[9].Timeout"}, err.(BindingErrors).Fields())
+	test.Equal(map[int]Endpoint{3: {}}, registry.Endpoints)
+}
+
+func TestDecoder_AppliesKeyTransform(t *testing.T) {
+	test := assert.New(t)
+
+	decoder := NewDecoder(
+		Prefix("app_"),
+		KeyFunc(func(name string) string {
+			return strings.Replace(name, ".", "_", -1)
+		}),
+		KeyTransform(strings.ToUpper),
+	)
+
+	var (
+		keys   []string
+		config struct {
+			Port     int
+			Database struct {
+				Host string
+			}
+		}
+	)
+
+	err := decoder.Bind(&config, func(key string) interface{} {
+		keys = append(keys, key)
+
+		switch key {
+		case "APP_PORT":
+			return "8080"
+		case "APP_DATABASE_HOST":
+			return "localhost"
+		default:
+			return nil
+		}
+	})
+
+	test.NoError(err)
+	test.Equal(8080, config.Port)
+	test.Equal("localhost", config.Database.Host)
+	test.Equal([]string{"APP_PORT", "APP_DATABASE_HOST"}, keys)
+	test.True(decoder.Config().HasKeyTransform)
+}
+
+func TestBind_CanBindJSONArrays(t *testing.T) {
+	test := assert.New(t)
+
+	var post struct {
+		Tags    []string   `binding:"jsonarray"`
+		Ratings []int      `binding:"jsonarray"`
+		Scores  *[]float64 `binding:"jsonarray"`
+		Authors []string   `binding:"jsonarray"`
+	}
+
+	err := Bind(&post, func(key string) interface{} {
+		switch key {
+		case "Tags":
+			return `["a,b", "c"]`
+		case "Ratings":
+			return `[1, 2, 3]`
+		case "Scores":
+			return `[0.5]`
+		case "Authors":
+			return `"john"`
+		default:
+			return nil
+		}
+	})
+
+	test.Equal([]string{"a,b", "c"}, post.Tags)
+	test.Equal([]int{1, 2, 3}, post.Ratings)
+	test.Equal(&[]float64{0.5}, post.Scores)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.Error(err.(BindingErrors).Field("Authors"))
+
+	var invalid struct {
+		Name string `binding:"jsonarray"`
+	}
+
+	err = Bind(&invalid, func(key string) interface{} { return `["a"]` })
+
+	test.IsType(InvalidBindingError(""), err)
+}
+
+func TestBind_CanBindTolerantRFC3339Times(t *testing.T) {
+	test := assert.New(t)
+
+	values := map[string]string{
+		"Strict":   "2024-05-10T12:30:00Z",
+		"Nano":     "2024-05-10T12:30:00.123456789+02:00",
+		"Space":    "2024-05-10 12:30:00.5Z",
+		"Local":    "2024-05-10T12:30:00",
+		"Both":     "2024-05-10 12:30:00",
+		"Invalid":  "10/05/2024",
+		"DateOnly": "2024-05-10",
+	}
+
+	var event struct {
+		Strict   time.Time `binding:"time:rfc3339"`
+		Nano     time.Time `binding:"time:rfc3339"`
+		Space    time.Time `binding:"time:rfc3339"`
+		Local    time.Time `binding:"time:rfc3339;tz=Europe/Berlin"`
+		Both     time.Time `binding:"time:rfc3339"`
+		Invalid  time.Time `binding:"time:rfc3339"`
+		DateOnly time.Time `binding:"time:rfc3339"`
+	}
+
+	err := Bind(&event, func(key string) interface{} {
+		return values[key]
+	})
+
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	test.Equal(time.Date(2024, 5, 10, 12, 30, 0, 0, time.UTC), event.Strict)
+	test.True(
+		time.Date(2024, 5, 10, 10, 30, 0, 123456789, time.UTC).
+			Equal(event.Nano),
+	)
+	test.Equal(
+		time.Date(2024, 5, 10, 12, 30, 0, 500000000, time.UTC),
+		event.Space,
+	)
+	test.True(time.Date(2024, 5, 10, 12, 30, 0, 0, berlin).Equal(event.Local))
+	test.Equal(time.Date(2024, 5, 10, 12, 30, 0, 0, time.UTC), event.Both)
+
+	test.Error(err)
+	test.Len(err, 2)
+	test.EqualError(
+		err.(BindingErrors).Field("Invalid"),
+		`Invalid — invalid RFC 3339 time: "10/05/2024"`,
+	)
+	test.Error(err.(BindingErrors).Field("DateOnly"))
+}
+
+func TestBind_CanSkipUnbindableFields(t *testing.T) {
+	test := assert.New(t)
+
+	var service struct {
+		Name    string
+		Events  chan string
+		Handler func()
+		Retries uint
+		Port    int    `binding:"int"`
+		Secret  []byte `binding:"vault"`
+	}
+
+	mapper := func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "api"
+		case "Port":
+			return "http"
+		default:
+			return "1"
+		}
+	}
+
+	err := Bind(&service, mapper)
+	test.IsType(InvalidBindingError(""), err)
+
+	err = Bind(&service, mapper, SkipUnbindable(true))
+	test.IsType(InvalidBindingError(""), err)
+
+	err = Bind(
+		&service,
+		mapper,
+		SkipUnbindable(true),
+		WithBinding("vault", func(
+			data interface{},
+			_ string,
+		) (interface{}, error) {
+			return []byte(data.(string)), nil
+		}),
+	)
+
+	test.Equal("api", service.Name)
+	test.Nil(service.Events)
+	test.Equal(uint(0), service.Retries)
+	test.Equal([]byte("1"), service.Secret)
+
+	test.Error(err)
+	test.Len(err, 1)
+	test.Error(err.(BindingErrors).Field("Port"))
+}
+
+func TestBindInto_ReturnsBoundValue(t *testing.T) {
+	test := assert.New(t)
+
+	type user struct {
+		Name string
+		Age  int
+	}
+
+	type server struct {
+		Host string `default:"localhost"`
+		Port int    `required:"true"`
+		Bad  chan int
+	}
+
+	bound, err := BindInto[user](func(key string) interface{} {
+		switch key {
+		case "Name":
+			return "John"
+		case "Age":
+			return "many"
+		default:
+			return nil
+		}
+	})
+
+	test.Equal(user{Name: "John"}, bound)
+	test.Error(err)
+	test.Len(err, 1)
+	test.Error(err.(BindingErrors).Field("Age"))
+
+	config, err := BindInto[server](func(key string) interface{} {
+		return "80"
+	})
+
+	test.Equal(server{}, config)
+	test.IsType(InvalidBindingError(""), err)
+
+	config, err = BindInto[server](
+		func(key string) interface{} {
+			if key == "Port" {
+				return "80"
+			}
+
+			return nil
+		},
+		SkipUnbindable(true),
+	)
+
+	test.NoError(err)
+	test.Equal(server{Host: "localhost", Port: 80}, config)
+}
+
+type testAppendTags struct {
+	items []string
+}
+
+func (tags *testAppendTags) Add(tag string) {
+	tags.items = append(tags.items, tag)
+}
+
+type testAppendPorts struct {
+	items []int
+}
+
+func (ports *testAppendPorts) Append(port int) error {
+	if port == 0 {
+		return errors.New("port should not be zero")
+	}
+
+	ports.items = append(ports.items, port)
+
+	return nil
+}
+
+type testAppendList struct {
+	list.List
+}
+
+func (list *testAppendList) Add(item interface{}) {
+	list.PushBack(item)
+}
+
+func TestBind_CanBindAppendableCollections(t *testing.T) {
+	test := assert.New(t)
+
+	var server struct {
+		Tags  testAppendTags   `binding:"append"`
+		Ports *testAppendPorts `binding:"append:int;sep=|"`
+		Queue *testAppendList  `binding:"append"`
+		Bad   testAppendPorts  `binding:"append"`
+	}
+
+	values := map[string]interface{}{
+		"Tags":  "web,api",
+		"Ports": "80|443",
+		"Queue": []string{"a", "b"},
+		"Bad":   "8080,x,0",
+	}
+
+	err := Bind(&server, func(key string) interface{} { return values[key] })
+
+	test.EqualError(
+		err,
+		`Bad[1] — strconv.ParseInt: parsing "x": invalid syntax; `+
+			`Bad[2] — port should not be zero`,
+	)
+	test.Equal([]string{"web", "api"}, server.Tags.items)
+	test.Equal([]int{80, 443}, server.Ports.items)
+
+	var queue []interface{}
+	for item := server.Queue.Front(); item != nil; item = item.Next() {
+		queue = append(queue, item.Value)
+	}
+
+	test.Equal([]interface{}{"a", "b"}, queue)
+}
+
+type testAppendMoney struct {
+	Amount   int
+	Currency string
+}
+
+func (money *testAppendMoney) Add(other testAppendMoney) {
+	money.Amount += other.Amount
+}
+
+type testAppendCounter struct {
+	Hits int
+}
+
+func (counter *testAppendCounter) Add(hits int) {
+	counter.Hits += hits
+}
+
+func TestBind_BindsNestedStructsWithAppendMethods(t *testing.T) {
+	test := assert.New(t)
+
+	var order struct {
+		Price testAppendMoney
+		Stats testAppendCounter
+	}
+
+	values := map[string]interface{}{
+		"Price.Amount":   "100",
+		"Price.Currency": "USD",
+		"Stats.Hits":     "5",
+	}
+
+	err := Bind(&order, func(key string) interface{} { return values[key] })
+
+	test.NoError(err)
+	test.Equal(testAppendMoney{100, "USD"}, order.Price)
+	test.Equal(testAppendCounter{5}, order.Stats)
+}
+
+func TestBind_RecoversPanicsInBindings(t *testing.T) {
+	test := assert.New(t)
+
+	var config struct {
+		Port  int    `binding:"broken"`
+		Label string `binding:"brokenfield"`
+	}
+
+	mapper := func(string) interface{} { return "1" }
+
+	err := Bind(&config, mapper, Bindings{
+		"broken": func(interface{}, string) (interface{}, error) {
+			panic("oops")
+		},
+	})
+
+	test.EqualError(err, "binding of Port panicked: oops")
+
+	config.Port = 0
+
+	err = Bind(&config, func(key string) interface{} {
+		if key == "Port" {
+			return nil
+		}
+
+		return "1"
+	}, Bindings{"broken": bindInt}, FieldBindings{
+		"brokenfield": func(Field, string) (interface{}, error) {
+			var labels map[string]string
+
+			labels["a"] = "b"
+
+			return nil, nil
+		},
+	})
+
+	test.IsType(InvalidBindingError(""), err)
+	test.Contains(err.Error(), "binding of Label panicked")
+}
+
+func FuzzBindings(f *testing.F) {
+	f.Add("1", "")
+	f.Add("-1.5e3", "32")
+	f.Add("0x1f", "64,16")
+	f.Add("true", "%d")
+	f.Add("P1Y2M3DT4H5M6S", "approximate")
+	f.Add("+1 (555) 123-4567", "10,11")
+	f.Add("John <john@example.com>", "strict")
+	f.Add("2006-01-02T15:04:05Z", "rfc3339")
+	f.Add("a,b|c", "a|b")
+	f.Add("550e8400-e29b-41d4-a716-446655440000", "")
+	f.Add("3/4", ":")
+
+	bindings := newConfig(nil).bindings
+
+	f.Fuzz(func(t *testing.T, value string, opts string) {
+		for name, binding := range bindings {
+			func() {
+				defer func() {
+					if reason := recover(); reason != nil {
+						t.Fatalf(
+							"binding %q panicked on %q with options %q: %v",
+							name,
+							value,
+							opts,
+							reason,
+						)
+					}
+				}()
+
+				_, _ = binding(value, opts)
+			}()
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package binding
+
+import (
+	"fmt"
+)
+
+// ComparisonError will be part of BindingErrors slice to describe failed
+// comparison of two fields specified by Compare option.
+type ComparisonError struct {
+	left     string
+	operator string
+	right    string
+}
+
+// Name returns name of the left field of comparison.
+func (err ComparisonError) Name() string {
+	return err.left
+}
+
+// Operator returns comparison operator, like `<`.
+func (err ComparisonError) Operator() string {
+	return err.operator
+}
+
+// Right returns name of the right field of comparison.
+func (err ComparisonError) Right() string {
+	return err.right
+}
+
+func (err ComparisonError) Error() string {
+	return fmt.Sprintf(
+		`%s — value should be %s %s`,
+		err.Name(),
+		err.Operator(),
+		err.Right(),
+	)
+}
@@ -1,6 +1,7 @@
 package binding
 
 import (
+	"errors"
 	"fmt"
 )
 
@@ -19,6 +20,27 @@ func (err BindingError) Cause() error {
 	return err.cause
 }
 
+// Code returns machine-readable error code if error returned by binding
+// function (or any error it wraps) has `Code() string` method. Empty string
+// is returned otherwise.
+func (err BindingError) Code() string {
+	var coder interface {
+		Code() string
+	}
+
+	if errors.As(err.cause, &coder) {
+		return coder.Code()
+	}
+
+	return ""
+}
+
+// Unwrap returns error returned by binding function, so errors.Is and
+// errors.As can be used to inspect it.
+func (err BindingError) Unwrap() error {
+	return err.cause
+}
+
 func (err BindingError) Error() string {
 	return fmt.Sprintf(
 		`%s — %s`,
@@ -1,6 +1,7 @@
 package binding
 
 import (
+	"reflect"
 	"strings"
 )
 
@@ -8,6 +9,13 @@ import (
 // that can't be successfully bind to specified struct.
 type BindingErrors []error
 
+// fieldError is implemented by every error which is related to specific
+// field.
+type fieldError interface {
+	error
+	Name() string
+}
+
 func (errors BindingErrors) Error() string {
 	messages := []string{}
 
@@ -21,17 +29,73 @@ func (errors BindingErrors) Error() string {
 // Field returns error for specific field name if any.
 func (errors BindingErrors) Field(name string) error {
 	for _, err := range errors {
-		switch err := err.(type) {
-		case RequiredError:
-			if err.Name() == name {
-				return err
-			}
-		case BindingError:
-			if err.Name() == name {
-				return err
-			}
+		if err, ok := err.(fieldError); ok && err.Name() == name {
+			return err
 		}
 	}
 
 	return nil
 }
+
+// HasField reports whether there is error for specific field name.
+func (errors BindingErrors) HasField(name string) bool {
+	return errors.Field(name) != nil
+}
+
+// Len returns number of errors.
+func (errors BindingErrors) Len() int {
+	return len(errors)
+}
+
+// First returns the first error or nil if there are no errors.
+func (errors BindingErrors) First() error {
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors[0]
+}
+
+// Last returns the last error or nil if there are no errors. Note, that if
+// errors are truncated because of MaxErrors option, it's TruncatedError.
+func (errors BindingErrors) Last() error {
+	if len(errors) == 0 {
+		return nil
+	}
+
+	return errors[len(errors)-1]
+}
+
+// Fields returns names of all fields which have errors, in order of errors.
+func (errors BindingErrors) Fields() []string {
+	var (
+		names = []string{}
+		seen  = map[string]bool{}
+	)
+
+	for _, err := range errors {
+		if err, ok := err.(fieldError); ok && !seen[err.Name()] {
+			seen[err.Name()] = true
+			names = append(names, err.Name())
+		}
+	}
+
+	return names
+}
+
+// Filter returns only errors which have the same type as kind, e.g.
+// `errors.Filter(RequiredError{})` returns only errors for missing fields.
+func (errors BindingErrors) Filter(kind error) BindingErrors {
+	var (
+		result   = BindingErrors{}
+		kindType = reflect.TypeOf(kind)
+	)
+
+	for _, err := range errors {
+		if reflect.TypeOf(err) == kindType {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,54 @@
+package binding
+
+import (
+	"fmt"
+)
+
+// LengthError will be part of BindingErrors slice to describe slice field
+// which number of elements is out of range specified by `minitems` and
+// `maxitems` tags.
+type LengthError struct {
+	name   string
+	actual int
+	min    int
+	max    int
+}
+
+func (err LengthError) Name() string {
+	return err.name
+}
+
+// Actual returns number of mapped elements.
+func (err LengthError) Actual() int {
+	return err.actual
+}
+
+// Min returns minimal allowed number of elements, which is zero if not
+// limited.
+func (err LengthError) Min() int {
+	return err.min
+}
+
+// Max returns maximal allowed number of elements, which is zero if not
+// limited.
+func (err LengthError) Max() int {
+	return err.max
+}
+
+func (err LengthError) Error() string {
+	if err.max > 0 && err.actual > err.max {
+		return fmt.Sprintf(
+			`%s — %d items given, but at most %d allowed`,
+			err.Name(),
+			err.actual,
+			err.max,
+		)
+	}
+
+	return fmt.Sprintf(
+		`%s — %d items given, but at least %d required`,
+		err.Name(),
+		err.actual,
+		err.min,
+	)
+}
@@ -0,0 +1,30 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OneOfError will be part of BindingErrors slice to describe field which
+// value is not one of values listed in `oneof` tag.
+type OneOfError struct {
+	name   string
+	values []string
+}
+
+func (err OneOfError) Name() string {
+	return err.name
+}
+
+// Values returns list of allowed values.
+func (err OneOfError) Values() []string {
+	return err.values
+}
+
+func (err OneOfError) Error() string {
+	return fmt.Sprintf(
+		`%s — value should be one of: %s`,
+		err.Name(),
+		strings.Join(err.Values(), ", "),
+	)
+}
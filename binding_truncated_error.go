@@ -0,0 +1,23 @@
+package binding
+
+import (
+	"fmt"
+)
+
+// TruncatedError will be the last element of BindingErrors slice if binding
+// was stopped because MaxErrors limit was reached.
+type TruncatedError struct {
+	limit int
+}
+
+// Limit returns number of errors after which binding was stopped.
+func (err TruncatedError) Limit() int {
+	return err.limit
+}
+
+func (err TruncatedError) Error() string {
+	return fmt.Sprintf(
+		`too many errors, binding stopped after %d errors`,
+		err.Limit(),
+	)
+}
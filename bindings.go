@@ -1,19 +1,94 @@
 package binding
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
+	"math/cmplx"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 // Bindings is a map of binding function to it's name in `binding` tag.
 type Bindings map[string]BindFunc
 
+// Register registers binding function under given name and returns
+// bindings, so calls can be chained. If bindings is nil, new Bindings is
+// allocated and returned.
+func (bindings Bindings) Register(name string, fn BindFunc) Bindings {
+	if bindings == nil {
+		bindings = Bindings{}
+	}
+
+	bindings[name] = fn
+
+	return bindings
+}
+
+// Merge returns new Bindings which contains bindings from both sets. Bindings
+// from other take precedence. Neither of sets is modified.
+func (bindings Bindings) Merge(other Bindings) Bindings {
+	result := Bindings{}
+
+	for name, fn := range bindings {
+		result[name] = fn
+	}
+
+	for name, fn := range other {
+		result[name] = fn
+	}
+
+	return result
+}
+
+// WithBinding returns option which registers single binding function under
+// given name. It's a shorthand for `Bindings{name: fn}`.
+func WithBinding(name string, fn BindFunc) Bindings {
+	return Bindings{name: fn}
+}
+
+// ExtendedBindings returns bindings for common types which are not
+// registered by default. They can be passed to Bind as option:
+//
+//	binding.Bind(&output, mapper, binding.ExtendedBindings())
+//
+// It registers following bindings:
+//
+//   - `bool`, `time` and `duration`, which are same as built-in ones;
+//   - `uint`, which parses mapped value into uint, uint8, uint16, uint32 and
+//     uint64 types and accepts arguments in the form of `uint:<bits>,<base>`
+//     like `int` binding;
+//   - `url`, which parses mapped value into url.URL using url.Parse;
+//   - `ip`, which parses mapped value into net.IP using net.ParseIP.
+//
+// Bindings `uint`, `url` and `ip` are used by default for fields of
+// corresponding types, so Bind fails for such fields if ExtendedBindings is
+// not passed.
+func ExtendedBindings() Bindings {
+	return Bindings{
+		"bool":     bindBool,
+		"uint":     bindUint,
+		"time":     bindTime,
+		"duration": bindDuration,
+		"url":      bindURL,
+		"ip":       bindIP,
+	}
+}
+
 // BindFunc is a binding function signature which is used as parser for every
 // mapped value.
 //
-// First argument is mapped value to be parsed. Only strings are supported for
-// now.
+// First argument is mapped value to be parsed. It's either string, []string
+// or native bool or numeric value.
 //
 // Second argument is optional argument string that can control binding
 // function execution (like set bitness for ints), which is specified after
@@ -21,27 +96,48 @@ type Bindings map[string]BindFunc
 type BindFunc func(interface{}, string) (interface{}, error)
 
 func bindInt(data interface{}, opts string) (interface{}, error) {
+	return bindLocalizedInt(data, opts, nil)
+}
+
+// bindLocalizedInt parses int using given locales in addition to built-in
+// ones for `locale` option.
+func bindLocalizedInt(
+	data interface{},
+	opts string,
+	locales Locales,
+) (interface{}, error) {
 	var (
 		bits = 0
 		base = 10
 	)
 
-	_, err := fmt.Sscanf(opts, "%d,%d", &bits, &base)
+	format, options := parseOptions(opts)
+
+	_, err := fmt.Sscanf(format, "%d,%d", &bits, &base)
 	if err != nil && !strings.HasSuffix(err.Error(), "EOF") {
 		return nil, InvalidBindingError(err.Error())
 	}
 
+	if err := checkIntOptions(bits, base); err != nil {
+		return nil, err
+	}
+
 	if _, ok := data.(string); !ok {
 		return nil, InvalidBindingError(
 			fmt.Sprintf("only strings are supported, but %T given", data),
 		)
 	}
 
-	result, err := strconv.ParseInt(data.(string), base, bits)
+	value, err := normalizeNumber(data.(string), options["locale"], locales)
 	if err != nil {
 		return nil, err
 	}
 
+	result, err := strconv.ParseInt(value, base, bits)
+	if err != nil {
+		return nil, wrapNumError(err)
+	}
+
 	switch bits {
 	case 8:
 		return int8(result), nil
@@ -56,12 +152,87 @@ func bindInt(data interface{}, opts string) (interface{}, error) {
 	}
 }
 
+// checkIntOptions checks that bits and base options of `int` and `uint`
+// bindings are supported by strconv.
+func checkIntOptions(bits int, base int) error {
+	switch bits {
+	case 0, 8, 16, 32, 64:
+	default:
+		return InvalidBindingError(
+			fmt.Sprintf(
+				"bits should be one of 0, 8, 16, 32 or 64, but %d given",
+				bits,
+			),
+		)
+	}
+
+	if base != 0 && (base < 2 || base > 36) {
+		return InvalidBindingError(
+			fmt.Sprintf("base should be 0 or from 2 to 36, but %d given", base),
+		)
+	}
+
+	return nil
+}
+
+func bindUint(data interface{}, opts string) (interface{}, error) {
+	var (
+		bits = 0
+		base = 10
+	)
+
+	_, err := fmt.Sscanf(opts, "%d,%d", &bits, &base)
+	if err != nil && !strings.HasSuffix(err.Error(), "EOF") {
+		return nil, InvalidBindingError(err.Error())
+	}
+
+	if err := checkIntOptions(bits, base); err != nil {
+		return nil, err
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	result, err := strconv.ParseUint(data.(string), base, bits)
+	if err != nil {
+		return nil, wrapNumError(err)
+	}
+
+	switch bits {
+	case 8:
+		return uint8(result), nil
+	case 16:
+		return uint16(result), nil
+	case 32:
+		return uint32(result), nil
+	case 64:
+		return uint64(result), nil
+	default:
+		return uint(result), nil
+	}
+}
+
 func bindFloat(data interface{}, opts string) (interface{}, error) {
+	return bindLocalizedFloat(data, opts, nil)
+}
+
+// bindLocalizedFloat parses float using given locales in addition to
+// built-in ones for `locale` option.
+func bindLocalizedFloat(
+	data interface{},
+	opts string,
+	locales Locales,
+) (interface{}, error) {
 	var (
 		bits = 32
 	)
 
-	_, err := fmt.Sscanf(opts, "%d", &bits)
+	format, options := parseOptions(opts)
+
+	_, err := fmt.Sscanf(format, "%d", &bits)
 	if err != nil && !strings.HasSuffix(err.Error(), "EOF") {
 		return nil, InvalidBindingError(err.Error())
 	}
@@ -72,11 +243,16 @@ func bindFloat(data interface{}, opts string) (interface{}, error) {
 		)
 	}
 
-	result, err := strconv.ParseFloat(data.(string), bits)
+	value, err := normalizeNumber(data.(string), options["locale"], locales)
 	if err != nil {
 		return nil, err
 	}
 
+	result, err := strconv.ParseFloat(value, bits)
+	if err != nil {
+		return nil, wrapNumError(err)
+	}
+
 	switch bits {
 	case 32:
 		return float32(result), nil
@@ -87,6 +263,475 @@ func bindFloat(data interface{}, opts string) (interface{}, error) {
 	}
 }
 
+func bindComplex(data interface{}, opts string) (interface{}, error) {
+	var (
+		format, options = parseOptions(opts)
+
+		bits  = 128
+		polar = false
+	)
+
+	for _, option := range strings.Split(format, ",") {
+		switch option {
+		case "":
+		case "64", "128":
+			bits, _ = strconv.Atoi(option)
+		case "polar":
+			polar = true
+		default:
+			return nil, InvalidBindingError(
+				fmt.Sprintf("unknown complex option: %q", option),
+			)
+		}
+	}
+
+	unit := options["unit"]
+	if unit != "" && unit != "deg" && unit != "rad" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("unknown angle unit: %q", unit),
+		)
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	var (
+		result complex128
+		err    error
+	)
+
+	if polar {
+		result, err = parsePolar(data.(string), unit)
+	} else {
+		result, err = strconv.ParseComplex(data.(string), bits)
+		err = wrapNumError(err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if bits == 64 {
+		return complex64(result), nil
+	}
+
+	return result, nil
+}
+
+// parsePolar parses complex number in polar form, like `2∠45deg` or
+// `2∠0.78rad`. Angles without unit suffix are in given unit, which is
+// radians by default.
+func parsePolar(value string, unit string) (complex128, error) {
+	parts := strings.Split(value, "∠")
+	if len(parts) != 2 {
+		return 0, fmt.Errorf("invalid polar form: %q", value)
+	}
+
+	magnitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return 0, wrapNumError(err)
+	}
+
+	angle := strings.TrimSpace(parts[1])
+
+	for _, suffix := range []string{"deg", "rad"} {
+		if strings.HasSuffix(angle, suffix) {
+			angle = strings.TrimSuffix(angle, suffix)
+			unit = suffix
+		}
+	}
+
+	phase, err := strconv.ParseFloat(angle, 64)
+	if err != nil {
+		return 0, wrapNumError(err)
+	}
+
+	if unit == "deg" {
+		phase = phase * math.Pi / 180
+	}
+
+	return cmplx.Rect(magnitude, phase), nil
+}
+
+func bindRat(data interface{}, _ string) (interface{}, error) {
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	result, ok := new(big.Rat).SetString(data.(string))
+	if !ok {
+		return nil, fmt.Errorf("invalid rational number: %q", data)
+	}
+
+	return result, nil
+}
+
 func bindString(data interface{}, _ string) (interface{}, error) {
 	return data, nil
 }
+
+func bindBool(data interface{}, _ string) (interface{}, error) {
+	switch data := data.(type) {
+	case string:
+		return strconv.ParseBool(data)
+	case bool:
+		return data, nil
+	}
+
+	value := reflect.ValueOf(data)
+
+	var number float64
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64:
+		number = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		number = value.Float()
+	default:
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"only strings and bools are supported, but %T given",
+				data,
+			),
+		)
+	}
+
+	switch number {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return nil, fmt.Errorf("invalid bool value: %v", data)
+	}
+}
+
+func bindURL(data interface{}, _ string) (interface{}, error) {
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	result, err := url.Parse(data.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	return *result, nil
+}
+
+func bindIP(data interface{}, _ string) (interface{}, error) {
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	result := net.ParseIP(data.(string))
+	if result == nil {
+		return nil, fmt.Errorf("invalid IP address: %q", data)
+	}
+
+	return result, nil
+}
+
+// bindEmail parses email address using mail.ParseAddress into
+// mail.Address. Option `strict` rejects addresses with display names, like
+// `John <john@example.com>`, and option `address` makes binding return only
+// address as string, so it can be used for string fields. Options can be
+// combined, like `email:strict,address`.
+func bindEmail(data interface{}, opts string) (interface{}, error) {
+	var strict, addressOnly bool
+
+	for _, option := range strings.Split(opts, ",") {
+		switch option {
+		case "":
+		case "strict":
+			strict = true
+		case "address":
+			addressOnly = true
+		default:
+			return nil, InvalidBindingError(
+				fmt.Sprintf("unknown email option: %q", option),
+			)
+		}
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	address, err := mail.ParseAddress(data.(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid email address: %q", data)
+	}
+
+	if strict && address.Name != "" {
+		return nil, fmt.Errorf("email address has display name: %q", data)
+	}
+
+	if addressOnly {
+		return address.Address, nil
+	}
+
+	return *address, nil
+}
+
+func bindError(data interface{}, _ string) (interface{}, error) {
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	if data.(string) == "" {
+		return nil, nil
+	}
+
+	return errors.New(data.(string)), nil
+}
+
+func bindUUIDBytes(data interface{}, _ string) (interface{}, error) {
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	uuid := data.(string)
+
+	if len(uuid) != 36 || uuid[8] != '-' || uuid[13] != '-' ||
+		uuid[18] != '-' || uuid[23] != '-' {
+		return nil, fmt.Errorf("invalid UUID format: %q", uuid)
+	}
+
+	result, err := hex.DecodeString(strings.Replace(uuid, "-", "", -1))
+	if err != nil {
+		return nil, fmt.Errorf("invalid UUID format: %q", uuid)
+	}
+
+	return result, nil
+}
+
+func bindFlags(data interface{}, opts string) (interface{}, error) {
+	bits := map[string]uint64{}
+
+	for _, flag := range strings.Split(opts, "|") {
+		pair := strings.SplitN(flag, "=", 2)
+		if len(pair) != 2 {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("invalid flag specification: %q", flag),
+			)
+		}
+
+		bit, err := strconv.ParseUint(pair[1], 10, 64)
+		if err != nil {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("invalid flag specification: %q", flag),
+			)
+		}
+
+		bits[pair[0]] = bit
+	}
+
+	var names []string
+
+	switch data := data.(type) {
+	case string:
+		if data != "" {
+			names = strings.Split(data, ",")
+		}
+	case []string:
+		names = data
+	default:
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	var result uint64
+
+	for _, name := range names {
+		bit, ok := bits[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown flag: %q", name)
+		}
+
+		result |= bit
+	}
+
+	return result, nil
+}
+
+func bindTime(data interface{}, opts string) (interface{}, error) {
+	layout, options := parseOptions(opts)
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	location := time.UTC
+
+	if tz, ok := options["tz"]; ok {
+		var err error
+
+		location, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("unknown time zone: %q", tz),
+			)
+		}
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	if data.(string) == "" {
+		return nil, nil
+	}
+
+	if layout == "rfc3339" {
+		return parseRFC3339(data.(string), location)
+	}
+
+	return time.ParseInLocation(layout, data.(string), location)
+}
+
+// rfc3339Layouts lists layouts which are tried by `time:rfc3339` binding in
+// order. Fractional seconds are accepted by time.Parse for every layout.
+var rfc3339Layouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// parseRFC3339 parses time using first of rfc3339Layouts which matches.
+// Values without time zone are parsed in given location.
+func parseRFC3339(value string, location *time.Location) (time.Time, error) {
+	for _, layout := range rfc3339Layouts {
+		result, err := time.ParseInLocation(layout, value, location)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid RFC 3339 time: %q", value)
+}
+
+// getSecondsDuration converts number of seconds parsed from given value into
+// time.Duration. NaN is reported as SyntaxError, while infinite values and
+// values which overflow time.Duration are reported as RangeError.
+func getSecondsDuration(value string, seconds float64) (time.Duration, error) {
+	if math.IsNaN(seconds) {
+		return 0, SyntaxError{
+			cause: &strconv.NumError{
+				Func: "ParseFloat",
+				Num:  value,
+				Err:  strconv.ErrSyntax,
+			},
+		}
+	}
+
+	nanoseconds := seconds * float64(time.Second)
+
+	// float64(math.MaxInt64) is rounded up to 2^63, so it's out of range.
+	if nanoseconds >= math.MaxInt64 || nanoseconds < math.MinInt64 {
+		return 0, RangeError{
+			cause: &strconv.NumError{
+				Func: "ParseFloat",
+				Num:  value,
+				Err:  strconv.ErrRange,
+			},
+		}
+	}
+
+	return time.Duration(nanoseconds), nil
+}
+
+var durationUnits = map[string]bool{
+	"ns": true, "us": true, "µs": true, "ms": true,
+	"s": true, "m": true, "h": true,
+}
+
+func bindDuration(data interface{}, opts string) (interface{}, error) {
+	format, options := parseOptions(opts)
+
+	switch format {
+	case "":
+	case "seconds":
+		if _, ok := data.(string); !ok {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("only strings are supported, but %T given", data),
+			)
+		}
+
+		seconds, err := strconv.ParseFloat(data.(string), 64)
+		if err != nil {
+			return nil, wrapNumError(err)
+		}
+
+		return getSecondsDuration(data.(string), seconds)
+	default:
+		return nil, InvalidBindingError(
+			fmt.Sprintf("unknown duration format: %q", format),
+		)
+	}
+
+	unit, ok := options["unit"]
+	if ok && !durationUnits[unit] {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("unknown duration unit: %q", unit),
+		)
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	value := data.(string)
+
+	last, _ := utf8.DecodeLastRuneInString(value)
+	if unit != "" && !unicode.IsLetter(last) {
+		value += unit
+	}
+
+	return time.ParseDuration(value)
+}
+
+// parseOptions splits binding options string into positional part and named
+// options, which are specified as `key=value` pairs separated by `;`, like
+// `64;locale=de` or `unit=s`.
+func parseOptions(opts string) (string, map[string]string) {
+	var (
+		positional = ""
+		named      = map[string]string{}
+	)
+
+	for i, option := range strings.Split(opts, ";") {
+		pair := strings.SplitN(option, "=", 2)
+		if len(pair) == 2 {
+			named[pair[0]] = pair[1]
+		} else if i == 0 {
+			positional = option
+		}
+	}
+
+	return positional, named
+}
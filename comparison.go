@@ -0,0 +1,318 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Comparison describes rule which compares bound values of two fields,
+// like `Comparison{"Start", "<", "End"}`. Fields are specified by names
+// which are used in errors, like `Period.Start` for nested fields.
+// Supported operators are `==`, `!=`, `<`, `<=`, `>` and `>=`; ordering
+// operators can be used for numbers, strings, durations and time.Time.
+type Comparison struct {
+	Left     string
+	Operator string
+	Right    string
+}
+
+// Compare option specifies comparisons which are checked after binding,
+// like `Compare{{"Password", "==", "Confirm"}}`. Comparisons are checked
+// only if both fields have bound values, so absent fields should be marked
+// as required. ComparisonError is reported for the left field if comparison
+// fails, and BindingError is reported for it if values can't be compared.
+// AssertBindable checks that compared fields exist and have comparable
+// types.
+type Compare []Comparison
+
+// capture remembers bound value of the field, so it can be used by
+// comparisons.
+func (binder *binder) capture(name string, value reflect.Value) {
+	if binder.values != nil {
+		binder.values[name] = value.Interface()
+	}
+}
+
+// checkComparisons checks comparisons specified by Compare option using
+// captured values of fields.
+func (binder *binder) checkComparisons() {
+	for _, comparison := range binder.config.comparisons {
+		left, ok := binder.values[comparison.Left]
+		if !ok {
+			continue
+		}
+
+		right, ok := binder.values[comparison.Right]
+		if !ok {
+			continue
+		}
+
+		// Nil pointers are considered absent.
+		if !reflect.Indirect(reflect.ValueOf(left)).IsValid() ||
+			!reflect.Indirect(reflect.ValueOf(right)).IsValid() {
+			continue
+		}
+
+		result, err := compareValues(left, right, comparison.Operator)
+		if err != nil {
+			binder.addError(comparison.Left, fmt.Errorf(
+				`can't compare with %s using %s: %s`,
+				comparison.Right,
+				comparison.Operator,
+				err,
+			))
+
+			continue
+		}
+
+		if !result {
+			binder.addError(comparison.Left, ComparisonError{
+				left:     comparison.Left,
+				operator: comparison.Operator,
+				right:    comparison.Right,
+			})
+		}
+	}
+}
+
+// assertComparisons checks that fields of comparisons specified by Compare
+// option exist in given struct type and their values can be compared using
+// specified operators.
+func assertComparisons(structType reflect.Type, config *config) error {
+	if len(config.comparisons) == 0 {
+		return nil
+	}
+
+	types := map[string]reflect.Type{}
+
+	getComparableTypes(structType, config, "", map[reflect.Type]bool{}, types)
+
+	for _, comparison := range config.comparisons {
+		for _, name := range []string{comparison.Left, comparison.Right} {
+			if _, ok := types[name]; !ok {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`comparison %s %s %s refers to unknown field %s`,
+						comparison.Left,
+						comparison.Operator,
+						comparison.Right,
+						name,
+					),
+				)
+			}
+		}
+
+		var (
+			left  = types[comparison.Left]
+			right = types[comparison.Right]
+		)
+
+		// Types of values stored in interfaces are known only after binding.
+		if left.Kind() == reflect.Interface ||
+			right.Kind() == reflect.Interface {
+			continue
+		}
+
+		_, err := compareValues(
+			reflect.New(left).Elem().Interface(),
+			reflect.New(right).Elem().Interface(),
+			comparison.Operator,
+		)
+		if err != nil {
+			return InvalidBindingError(
+				fmt.Sprintf(
+					`can't compare %s %s %s: %s`,
+					comparison.Left,
+					comparison.Operator,
+					comparison.Right,
+					err,
+				),
+			)
+		}
+	}
+
+	return nil
+}
+
+// getComparableTypes collects types of values which are captured for fields
+// of given struct type and nested structs, keyed by field names. Types which
+// are being visited are skipped, so recursive types are supported.
+func getComparableTypes(
+	structType reflect.Type,
+	config *config,
+	prefix string,
+	visiting map[reflect.Type]bool,
+	types map[string]reflect.Type,
+) {
+	if visiting[structType] {
+		return
+	}
+
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	structValue := reflect.New(structType).Elem()
+
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			field = structType.Field(i)
+			key   = config.getName(field, "from")
+			name  = prefix + key
+		)
+
+		if key == "" {
+			continue
+		}
+
+		_, hasTypeBinding := config.getTypeBinding(field)
+
+		if isNestedType(field) && !hasTypeBinding {
+			nestedType := field.Type
+			if nestedType.Kind() == reflect.Ptr {
+				nestedType = nestedType.Elem()
+			}
+
+			getComparableTypes(nestedType, config, name+".", visiting, types)
+
+			continue
+		}
+
+		valueType := field.Type
+		if setter, err := getSetter(structValue, field); err == nil &&
+			setter.IsValid() {
+			valueType = getSetterType(field, setter)
+		}
+
+		if valueType.Kind() == reflect.Ptr {
+			valueType = valueType.Elem()
+		}
+
+		types[name] = valueType
+	}
+}
+
+// compareValues compares given values using operator. Ordering operators
+// are supported for numbers, strings and time.Time, and equality operators
+// are supported for values of any comparable type.
+func compareValues(left, right interface{}, operator string) (bool, error) {
+	var (
+		leftValue  = reflect.Indirect(reflect.ValueOf(left))
+		rightValue = reflect.Indirect(reflect.ValueOf(right))
+	)
+
+	if !leftValue.IsValid() || !rightValue.IsValid() {
+		return false, fmt.Errorf("values are not set")
+	}
+
+	order, ordered := compareOrdered(leftValue, rightValue)
+
+	if !ordered {
+		if leftValue.Type() != rightValue.Type() ||
+			!leftValue.Type().Comparable() {
+			return false, fmt.Errorf(
+				"%s and %s are not comparable",
+				leftValue.Type(),
+				rightValue.Type(),
+			)
+		}
+
+		order = 1
+		if leftValue.Interface() == rightValue.Interface() {
+			order = 0
+		}
+	}
+
+	switch operator {
+	case "==":
+		return order == 0, nil
+	case "!=":
+		return order != 0, nil
+	}
+
+	if !ordered {
+		return false, fmt.Errorf("%s values are not ordered", leftValue.Type())
+	}
+
+	switch operator {
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	case ">=":
+		return order >= 0, nil
+	default:
+		return false, fmt.Errorf("unknown operator %q", operator)
+	}
+}
+
+// compareOrdered returns -1, 0 or 1 if left value is less than, equal or
+// greater than right value, or false if values can't be ordered.
+func compareOrdered(left, right reflect.Value) (int, bool) {
+	timeType := reflect.TypeOf(time.Time{})
+
+	if left.Type() == timeType && right.Type() == timeType {
+		var (
+			leftTime  = left.Interface().(time.Time)
+			rightTime = right.Interface().(time.Time)
+		)
+
+		return getOrder(leftTime.Before(rightTime), leftTime.After(rightTime)),
+			true
+	}
+
+	switch {
+	case isKind(left, intKinds) && isKind(right, intKinds):
+		return getOrder(left.Int() < right.Int(), left.Int() > right.Int()),
+			true
+	case isKind(left, uintKinds) && isKind(right, uintKinds):
+		return getOrder(left.Uint() < right.Uint(), left.Uint() > right.Uint()),
+			true
+	case isKind(left, floatKinds) && isKind(right, floatKinds):
+		return getOrder(
+			left.Float() < right.Float(),
+			left.Float() > right.Float(),
+		), true
+	case left.Kind() == reflect.String && right.Kind() == reflect.String:
+		return getOrder(
+			left.String() < right.String(),
+			left.String() > right.String(),
+		), true
+	default:
+		return 0, false
+	}
+}
+
+func getOrder(less bool, greater bool) int {
+	switch {
+	case less:
+		return -1
+	case greater:
+		return 1
+	default:
+		return 0
+	}
+}
+
+var (
+	intKinds = []reflect.Kind{
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+	}
+	uintKinds = []reflect.Kind{
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64,
+	}
+	floatKinds = []reflect.Kind{reflect.Float32, reflect.Float64}
+)
+
+func isKind(value reflect.Value, kinds []reflect.Kind) bool {
+	for _, kind := range kinds {
+		if value.Kind() == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// CSVColumn is a FieldNameFunc which uses zero-based column index specified
+// in `csv` tag as field name, like `csv:"2"`. Fields without valid `csv` tag
+// are skipped.
+//
+// It's intended to be used with BindCSVRecord for CSV files without header.
+func CSVColumn(field reflect.StructField) string {
+	index, err := strconv.Atoi(field.Tag.Get("csv"))
+	if err != nil || index < 0 {
+		return ""
+	}
+
+	return strconv.Itoa(index)
+}
+
+// BindCSVRecord binds CSV record, like one returned by csv.Reader.Read, into
+// output by column indices specified in `csv` tags, like `csv:"2"`. Empty
+// cells are bound as empty strings, so EmptyAsAbsent option can be used to
+// treat them as absent. If any field refers to column which is out of
+// record range, InvalidBindingError is returned.
+//
+// Options are the same as accepted by Bind; FieldNameFunc option is
+// overridden by CSVColumn.
+func BindCSVRecord(
+	output interface{},
+	record []string,
+	options ...interface{},
+) error {
+	options = append(options, FieldNameFunc(CSVColumn))
+
+	for _, field := range DescribeStruct(reflect.TypeOf(output), options...) {
+		index, err := strconv.Atoi(field.Name)
+		if err != nil {
+			continue
+		}
+
+		if index >= len(record) {
+			return InvalidBindingError(
+				fmt.Sprintf(
+					`column %d of field %s is out of record with %d columns`,
+					index,
+					field.Field,
+					len(record),
+				),
+			)
+		}
+	}
+
+	values := map[int]interface{}{}
+	for index, value := range record {
+		values[index] = value
+	}
+
+	return Bind(output, NumberedMapper(values), options...)
+}
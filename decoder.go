@@ -0,0 +1,135 @@
+package binding
+
+import (
+	"reflect"
+	"runtime"
+	"sort"
+)
+
+// Decoder binds values using options specified once on creation, which is
+// handy when same options are used for many Bind calls. Decoder is safe for
+// concurrent use.
+type Decoder struct {
+	options []interface{}
+}
+
+// KeyTransform option specifies function which is applied to every name
+// passed to mapper, like strings.ToUpper for binding from environment. It's
+// applied last, after Prefix and KeyFunc options, and is intended to set
+// naming convention for the whole Decoder:
+//
+//	decoder := binding.NewDecoder(binding.KeyTransform(strings.ToUpper))
+type KeyTransform func(name string) string
+
+// DecoderConfig is a read-only snapshot of effective configuration of
+// Decoder, which can be used for debugging and in tests.
+type DecoderConfig struct {
+	// Bindings is a sorted list of registered binding names.
+	Bindings []string
+
+	// FieldNameFunc is a name of the function which is used to obtain field
+	// names.
+	FieldNameFunc string
+
+	SliceSeparator string
+	SliceGaps      int
+	MaxErrors      int
+	Prefix         string
+	RequiredTagKey string
+
+	ReuseSlices           bool
+	RequireTaggedBindings bool
+	FailFast              bool
+	NestedMaps            bool
+	RequireBindableFields bool
+	StrictNames           bool
+	EmptyAsAbsent         bool
+	SkipZero              bool
+	IgnoreUnknownBindings bool
+	SkipUnbindable        bool
+	IgnoreUnknownArgs     bool
+	ZeroOnError           bool
+	TransactionalMaps     bool
+
+	HasFieldFilter  bool
+	HasKeyFunc      bool
+	HasRequiredFunc bool
+	HasKeyTransform bool
+}
+
+// NewDecoder returns Decoder which will use specified options. Options are
+// the same as accepted by Bind.
+func NewDecoder(options ...interface{}) *Decoder {
+	return &Decoder{
+		options: options,
+	}
+}
+
+// Bind binds values provided by mapper into output like Bind function does,
+// using decoder options. Additional options can be passed to override
+// decoder options for this call.
+func (decoder *Decoder) Bind(
+	output interface{},
+	mapper MapFunc,
+	options ...interface{},
+) error {
+	return bind(output, mapper, decoder.getConfig(options), nil)
+}
+
+// Config returns effective configuration of decoder.
+func (decoder *Decoder) Config() DecoderConfig {
+	config := decoder.getConfig(nil)
+
+	bindings := []string{}
+	for name := range config.bindings {
+		bindings = append(bindings, name)
+	}
+
+	for name := range config.fieldBindings {
+		if _, ok := config.bindings[name]; !ok {
+			bindings = append(bindings, name)
+		}
+	}
+
+	sort.Strings(bindings)
+
+	return DecoderConfig{
+		Bindings:      bindings,
+		FieldNameFunc: getFuncName(config.fieldNameFunc),
+
+		SliceSeparator: config.sliceSeparator,
+		SliceGaps:      config.sliceGaps,
+		MaxErrors:      config.maxErrors,
+		Prefix:         config.prefix,
+		RequiredTagKey: config.requiredTagKey,
+
+		ReuseSlices:           config.reuseSlices,
+		RequireTaggedBindings: config.requireTaggedBindings,
+		FailFast:              config.failFast,
+		NestedMaps:            config.nestedMaps,
+		RequireBindableFields: config.requireBindableFields,
+		StrictNames:           config.strictNames,
+		EmptyAsAbsent:         config.emptyAsAbsent,
+		SkipZero:              config.skipZero,
+		IgnoreUnknownBindings: config.ignoreUnknownBindings,
+		SkipUnbindable:        config.skipUnbindable,
+		IgnoreUnknownArgs:     config.ignoreUnknownArgs,
+		ZeroOnError:           config.zeroOnError,
+		TransactionalMaps:     config.transactionalMaps,
+
+		HasFieldFilter:  config.fieldFilter != nil,
+		HasKeyFunc:      config.keyFunc != nil,
+		HasRequiredFunc: config.requiredFunc != nil,
+		HasKeyTransform: config.keyTransform != nil,
+	}
+}
+
+func (decoder *Decoder) getConfig(options []interface{}) *config {
+	merged := append([]interface{}{}, decoder.options...)
+
+	return newConfig(append(merged, options...))
+}
+
+func getFuncName(fn interface{}) string {
+	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+}
@@ -0,0 +1,165 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// getReference returns name of the field which is referred by `default` tag
+// in the form of `default:"$<field>"`.
+func getReference(field reflect.StructField) (string, bool) {
+	value := field.Tag.Get("default")
+	if !strings.HasPrefix(value, "$") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(value, "$"), true
+}
+
+// bindReferences sets fields with given indices, which have no mapped values,
+// to values of fields referred by their `default` tags. It should be called
+// after all other fields of the struct are bound. Defaults are resolved in
+// order of dependencies, so they can refer to fields with defaults as well.
+// Values are passed to setters of fields, if any.
+func (binder *binder) bindReferences(
+	structValue reflect.Value,
+	fields []int,
+	prefix string,
+) error {
+	var (
+		structType = structValue.Type()
+		pending    = map[int]bool{}
+	)
+
+	for _, i := range fields {
+		pending[i] = true
+	}
+
+	for len(pending) > 0 {
+		resolved := false
+
+		for _, i := range fields {
+			if !pending[i] {
+				continue
+			}
+
+			field := structType.Field(i)
+			name, _ := getReference(field)
+
+			source, ok := structType.FieldByName(name)
+
+			if !ok || len(source.Index) != 1 || source.PkgPath != "" {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`default of %s.%s refers to unknown field %q`,
+						structType,
+						field.Name,
+						name,
+					),
+				)
+			}
+
+			if pending[source.Index[0]] {
+				continue
+			}
+
+			setter, err := getSetter(structValue, field)
+			if err != nil {
+				return err
+			}
+
+			target := reflect.New(field.Type).Elem()
+			if setter.IsValid() {
+				target = reflect.New(getSetterType(field, setter)).Elem()
+			} else if !structValue.Field(i).CanSet() {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`field %s.%s is unexported and can not be set`,
+						structType.Name(),
+						field.Name,
+					),
+				)
+			}
+
+			value := structValue.Field(source.Index[0]).Interface()
+			if !setValue(target, value) {
+				return InvalidBindingError(
+					fmt.Sprintf(
+						`default of %s.%s refers to %s, which can't be set`,
+						structType,
+						field.Name,
+						source.Type,
+					),
+				)
+			}
+
+			delete(pending, i)
+			resolved = true
+
+			fieldName := prefix + binder.config.getName(field, "from")
+
+			err = validateField(field, fieldName, target)
+			if err != nil {
+				binder.addError(fieldName, err)
+
+				if binder.config.zeroOnError && !setter.IsValid() {
+					structField := structValue.Field(i)
+					structField.Set(reflect.Zero(structField.Type()))
+				}
+
+				continue
+			}
+
+			binder.capture(fieldName, target)
+
+			if setter.IsValid() {
+				if err := callSetter(setter, target); err != nil {
+					binder.addError(fieldName, err)
+				}
+			} else {
+				structValue.Field(i).Set(target)
+			}
+		}
+
+		if !resolved {
+			return InvalidBindingError(
+				fmt.Sprintf(`defaults of %s are circular`, structType),
+			)
+		}
+	}
+
+	return nil
+}
+
+// isBindableDefault reports whether value from Defaults option should be
+// parsed using field's binding, which is the case for strings. Values of
+// other types are set as is.
+func isBindableDefault(value interface{}) bool {
+	switch value.(type) {
+	case string, []string:
+		return true
+	default:
+		return false
+	}
+}
+
+// setDefaultValue sets value from Defaults option into target as is.
+func setDefaultValue(
+	target reflect.Value,
+	name string,
+	value interface{},
+) error {
+	if !setValue(target, value) {
+		return InvalidBindingError(
+			fmt.Sprintf(
+				`default value of type %T can't be set into %s (%s)`,
+				value,
+				target.Type(),
+				name,
+			),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package binding
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FieldBinding describes how field of the struct is bound.
+type FieldBinding struct {
+	// Name is a name of the field which is passed to mapper and used in
+	// errors, like `Address.City`. Elements of nested slices and maps are
+	// denoted by `[]`, like `Items[].Name`.
+	Name string
+
+	// Field is a name of the field in Go struct.
+	Field string
+
+	// Type is a type of the field.
+	Type reflect.Type
+
+	// Binding is a binding with options which is used for the field, like
+	// `int:8`. It's empty if field is bound using TypeBindings option.
+	Binding string
+
+	// Required is set if field is required.
+	Required bool
+
+	// Default is a default value of the field, which is set only if
+	// HasDefault is set. DefaultRef is set instead of Default if default
+	// value is taken from other field, like `default:"$Login"`.
+	Default    string
+	DefaultRef string
+	HasDefault bool
+
+	// OneOf is a list of allowed values specified by `oneof` tag.
+	OneOf []string
+
+	// MinItems and MaxItems are limits of number of slice elements specified
+	// by `minitems` and `maxitems` tags. Zero means no limit.
+	MinItems int
+	MaxItems int
+}
+
+// DescribeStruct returns descriptions of every field of given struct type
+// which would be bound by Bind with the same options, which is useful for
+// generating form schemas and documentation. Fields of nested structs are
+// described instead of the nested struct fields itself. No values are bound
+// and bindings are not checked for being registered; use AssertBindable for
+// that.
+func DescribeStruct(
+	structType reflect.Type,
+	options ...interface{},
+) []FieldBinding {
+	for structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if structType.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return describeStruct(
+		structType,
+		newConfig(options),
+		"",
+		map[reflect.Type]bool{},
+	)
+}
+
+func describeStruct(
+	structType reflect.Type,
+	config *config,
+	prefix string,
+	described map[reflect.Type]bool,
+) []FieldBinding {
+	if described[structType] {
+		return nil
+	}
+
+	described[structType] = true
+	defer delete(described, structType)
+
+	var (
+		result      = []FieldBinding{}
+		structValue = reflect.New(structType).Elem()
+	)
+
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			field = structType.Field(i)
+			key   = config.getName(field, "from")
+			name  = prefix + key
+		)
+
+		if key == "" {
+			continue
+		}
+
+		if config.fieldFilter != nil && !config.fieldFilter(field) {
+			continue
+		}
+
+		var nestedPrefix string
+
+		_, hasTypeBinding := config.getTypeBinding(field)
+
+		if isInterfaceType(field) && !hasTypeBinding {
+			continue
+		}
+
+		switch {
+		case hasTypeBinding:
+		case isNestedType(field):
+			nestedPrefix = name + "."
+		case isNestedSliceType(field), isNestedMapType(field):
+			nestedPrefix = name + "[]."
+		}
+
+		if nestedPrefix != "" {
+			nestedType := field.Type
+			for nestedType.Kind() != reflect.Struct {
+				nestedType = nestedType.Elem()
+			}
+
+			result = append(
+				result,
+				describeStruct(nestedType, config, nestedPrefix, described)...,
+			)
+
+			continue
+		}
+
+		description := FieldBinding{
+			Name:     name,
+			Field:    field.Name,
+			Type:     field.Type,
+			Required: config.isRequired(field),
+			OneOf:    strings.Fields(field.Tag.Get("oneof")),
+		}
+
+		if !hasTypeBinding {
+			description.Binding = describeBinding(structValue, field, config)
+		}
+
+		description.Default, description.HasDefault = field.Tag.Lookup(
+			"default",
+		)
+
+		if reference, ok := getReference(field); ok {
+			description.Default, description.DefaultRef = "", reference
+		}
+
+		// Invalid limits are reported by AssertBindable.
+		description.MinItems, description.MaxItems, _ = getItemsLimits(field)
+
+		result = append(result, description)
+	}
+
+	return result
+}
+
+// describeBinding returns binding spec which is used by Bind for the field:
+// binding of setter argument type is used if field has setter, and default
+// binding of field type is used for unknown bindings if
+// IgnoreUnknownBindings option is set.
+func describeBinding(
+	structValue reflect.Value,
+	field reflect.StructField,
+	config *config,
+) string {
+	name, _ := parseBindingTag(field)
+	if _, ok := config.fieldBindings[name]; ok {
+		return getBindingSpec(field)
+	}
+
+	if setter, err := getSetter(structValue, field); err == nil &&
+		setter.IsValid() {
+		field.Type = getSetterType(field, setter)
+		name, _ = parseBindingTag(field)
+	}
+
+	_, known := config.bindings[name]
+	if known || strings.HasPrefix(name, "@") ||
+		strings.Contains(field.Tag.Get("binding"), "||") ||
+		!config.ignoreUnknownBindings {
+		return getBindingSpec(field)
+	}
+
+	spec := getBindingSpec(reflect.StructField{Type: field.Type})
+	if spec != "" {
+		return spec
+	}
+
+	return "string"
+}
@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// BindDiff binds values into output like Bind does and returns names of
+// fields which values differ from values of the same fields in baseline
+// after binding. Baseline should be struct (or pointer to struct) of the
+// same type as output. Names are returned in order of struct fields.
+//
+// Binding errors are returned along with names of fields which were
+// changed anyway.
+func BindDiff(
+	baseline interface{},
+	output interface{},
+	mapper MapFunc,
+	options ...interface{},
+) ([]string, error) {
+	var (
+		baselineValue = reflect.Indirect(reflect.ValueOf(baseline))
+		outputValue   = reflect.Indirect(reflect.ValueOf(output))
+	)
+
+	if !baselineValue.IsValid() || !outputValue.IsValid() ||
+		baselineValue.Type() != outputValue.Type() {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`baseline type %T does not match output type %T`,
+				baseline,
+				output,
+			),
+		)
+	}
+
+	config := newConfig(options)
+
+	err := bind(output, mapper, config, nil)
+	if _, ok := err.(BindingErrors); err != nil && !ok {
+		return nil, err
+	}
+
+	changed := []string{}
+
+	for i := 0; i < outputValue.NumField(); i++ {
+		field := outputValue.Type().Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := config.getName(field, "from")
+		if name == "" {
+			continue
+		}
+
+		if !reflect.DeepEqual(
+			baselineValue.Field(i).Interface(),
+			outputValue.Field(i).Interface(),
+		) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed, err
+}
@@ -0,0 +1,433 @@
+// Package binding offers easy way of binding form-like sources into structs.
+//
+// It's particularly useful with web-frameworks like gin.
+//
+// Package offers rich-structured errors which can be easily integrated into
+// UI error reports (like HTML page).
+//
+// # Tags
+//
+// Struct tags can be used to control binding. Following tags are inspected
+// by Bind: `binding`, `form`, `from`, `default`, `trim`, `coerce`,
+// `setter`, `required`, `oneof`, `minitems` and `maxitems`.
+//
+// Tag `binding` used to override binding function which will be used for
+// converting value returned by mapper function to struct's field type.
+//
+// Several bindings separated by `||` can be specified in `binding` tag, like
+// `binding:"int||string"`: they are tried in order and value returned by the
+// first binding which succeeds is used. If every binding fails, error of the
+// last one is reported. InvalidBindingError is returned immediately.
+//
+// Method of the struct can be used as binding by specifying it's name with
+// `@` prefix in `binding` tag, like `binding:"@ParseSlug"`. Method should be
+// exported and have signature `func(string) (T, error)`, where T is a type of
+// the field; otherwise InvalidBindingError is returned.
+//
+// Tag `form` can be used to override field name that will be passed into
+// mapper function to obtain value. Bind will also inspect `json`, `bson`,
+// `yaml`, `toml` and `xml` tags if `form` tag is not specified. If no known
+// tags specify mapped name, then field's name will be used. Fields with name
+// `-`, like `form:"-"`, are skipped. Several fields can have the same name,
+// so same mapped value is bound into each of them using it's own binding,
+// e.g. to split `full_name` into first and last names. Names are passed to
+// mapper verbatim, so flat struct can be bound from flattened source using
+// dotted names, like `form:"address.zip"`; such names are not split into
+// nested fields.
+//
+// Tag `from` can be used to specify name which is passed to mapper when it
+// differs from name emitted by Unbind, which is specified by `to` tag, like
+// `from:"user_name" to:"userName"`. Name is resolved in following order:
+// `from` tag (`to` tag for Unbind), then FieldNameFunc, which inspects
+// `form`, `json`, `bson`, `yaml`, `toml` and `xml` tags by default, and then
+// field's name. Field with `from:"-"` is not bound, and field with `to:"-"`
+// is not unbound.
+//
+// Tag `default` used to specify raw value which is used if mapper returns
+// no value for the field, like `default:"10"`. Default value is parsed by
+// field's binding as if it was returned by mapper, so required field with
+// default is never reported. Default in the form of `$<field>`, like
+// `default:"$Username"`, refers to other field of the same struct by it's Go
+// name: such fields are set after all other fields are bound to the value of
+// referred field. Defaults can refer to fields with defaults, but circular
+// references are reported as InvalidBindingError.
+//
+// Tag `trim` used to remove leading and trailing whitespace from mapped
+// string values, like `trim:"true"`. Tag can also specify chars which are
+// removed in addition to whitespace, like `trim:"$"`.
+//
+// Tag `coerce` used to replace some mapped string values before they are
+// passed to binding function, like `coerce:"Y=true,N=false"`. Values which
+// are not listed are passed as is.
+//
+// Mapped values are trimmed first, then coerced and then passed to binding
+// function, which can normalize them further, like `locale` option of `int`
+// and `float` bindings does. Both tags are applied to every element of slices
+// and maps and to default values as well.
+//
+// Tag `setter` used to specify method of the struct, which should be called
+// with bound value instead of setting the field directly, like
+// `setter:"SetEmail"`. Method should accept single argument, which type
+// determines default binding, and can return error, which is reported as
+// BindingError. Field itself can be unexported.
+//
+// Tag `required` used to specify, that field should have mapped value and
+// error will be reported otherwise. Tag should be specified as
+// `required:"true"`; any value accepted by strconv.ParseBool, like `1` or
+// `TRUE`, can be used as well. To use another tag, pass
+// `RequiredTagKey("<key>")`, like `RequiredTagKey("validate")`: such tag can
+// also contain comma-separated list with `required` item, like
+// `validate:"required,email"`.
+//
+// Tag `oneof` used to specify space-separated list of values, one of which
+// bound value should be equal to, e.g. `oneof:"red green blue"`. Bound value
+// is compared in it's string representation, so numbers can be listed as
+// well. Pointers are dereferenced and every element of slices is checked.
+// OneOfError will be reported otherwise and field will be left unchanged.
+//
+// Tags `minitems` and `maxitems` used to limit number of elements of slice
+// fields, including slices of nested structs, like `maxitems:"100"`.
+// LengthError will be reported and elements will not be bound if number of
+// mapped elements is out of range. Zero value, like `maxitems:"0"`, means
+// no limit. Elements of nested slices are bound only until limit is
+// exceeded; without `maxitems` tag they are limited to 10000. Tags are not
+// supported for other fields, like maps or fields with `kv` binding, which
+// is reported by AssertBindable.
+//
+// # Bindings
+//
+// There are four built-in functions: `int`, `float`, `string` and `bool`.
+// They used to parse mapped value into int, int8, int16, int32, int64,
+// float32, float64, string and bool types accordingly.
+//
+// More bindings, like `uint`, `url` and `ip`, are provided by
+// ExtendedBindings, which should be passed as option.
+//
+// Binding `int` accepts two arguments in the form of `int:<bits>,<base>`,
+// which are optional and can be used to override automatically detected
+// bitness of resulting int and base of 10.
+//
+// Binding `float` accepts one argument in the form of `float:<bits>`.
+//
+// Bindings `int` and `float` accept `locale` option, like
+// `float:64;locale=de`, which makes them to strip thousands separators and
+// normalize decimal point according to locale before parsing, so `1.234,56`
+// is parsed as `1234.56`. Unknown locale is reported as InvalidBindingError.
+// See Locales for list of built-in locales and how to add more.
+//
+// Bindings `int` and `float` report SyntaxError if mapped value is not a
+// number and RangeError if it doesn't fit into the field type. Both can be
+// obtained from BindingError using errors.As.
+//
+// Binding `complex` parses mapped value using strconv.ParseComplex, like
+// `1+2i`, and is used for complex64 and complex128 fields by default. It
+// accepts arguments in the form of `complex:<bits>,polar`, which are
+// optional. If `polar` is specified, mapped value is parsed in polar form
+// `<magnitude>∠<angle>`, like `2∠45deg` or `2∠0.78rad`; unit of angles
+// without suffix is radians and can be changed using `unit` option, like
+// `complex:polar;unit=deg`.
+//
+// Binding `string` has no arguments and do not apply any parsing to mapped
+// value.
+//
+// Binding `bool` has no arguments and accepts strings accepted by
+// strconv.ParseBool. Native bool values are accepted as is, and native
+// numeric values (like float64 produced by decoding JSON) are accepted if
+// they are 0 or 1.
+//
+// Binding `duration` parses mapped value using time.ParseDuration and is
+// used for time.Duration fields by default. It accepts option in the form of
+// `duration:unit=<unit>`, which specifies unit (like `s` or `ms`) for values
+// which have no unit suffix, so `5` will be parsed as `5s` for
+// `duration:unit=s`. Binding `duration:seconds` parses mapped value as
+// float number of seconds, so `1.5` is parsed as `1.5s`.
+//
+// Binding `isoduration` parses ISO 8601 duration, like `P1DT2H30M` or
+// `PT0.5S`, into time.Duration. Weeks are 7 days and days are 24 hours
+// regardless of daylight saving time. Years and months have no fixed length,
+// so they are reported as errors unless `isoduration:approximate` is used,
+// which approximates years as 365 days and months as 30 days.
+//
+// Binding `time` parses mapped value using time.Parse and is used for
+// time.Time fields by default. It accepts layout as an argument, which is
+// time.RFC3339 by default, and `tz` option which specifies location for
+// values without time zone, like
+// `time:2006-01-02 15:04;tz=America/New_York`. Empty string is bound as zero
+// time.Time (or nil *time.Time), like blank HTML date inputs; note, that
+// such value is not absent, so required field will not be reported unless
+// EmptyAsAbsent option is set.
+//
+// Binding `time:rfc3339` is a tolerant variant of RFC 3339 parsing, which
+// tries following layouts in order and fails only if none of them matches:
+// `2006-01-02T15:04:05Z07:00` (time.RFC3339),
+// `2006-01-02T15:04:05.999999999Z07:00` (time.RFC3339Nano),
+// `2006-01-02 15:04:05Z07:00` (space instead of `T`),
+// `2006-01-02T15:04:05` and `2006-01-02 15:04:05` (without time zone, so
+// location specified by `tz` option is used). Fractional seconds are
+// accepted by every layout.
+//
+// Binding `datetime` combines values of two keys, which hold date and time,
+// into time.Time field, like HTML date and time inputs. Keys are specified
+// by `date` and `time` options, like
+// `datetime:date=event_date,time=event_time`. Values are parsed using
+// `2006-01-02 15:04` or `2006-01-02 15:04:05` layout, which can be changed
+// using `layout` option.
+// Option `tz` specifies location like for `time` binding.
+//
+// Binding `rat` parses mapped value using big.Rat.SetString, like `3/4` or
+// `0.75`, and is used for big.Rat and *big.Rat fields by default.
+//
+// Binding `kv` parses string of key-value pairs, like `a=1,b=2`, into map
+// field with string or int keys. It accepts arguments in the form of
+// `kv:<binding>;pair=<separator>;kv=<separator>`, which are optional and
+// specify binding of values (default binding for map value type by default),
+// separator of pairs (`,` by default) and separator of key and value (`=` by
+// default), like `kv:int;pair=;;kv=:` for `a:1;b:2`. Options should be
+// specified in that order, since separators can contain `;`. Malformed pairs
+// and values are reported with key, like `Labels[env]`.
+//
+// Binding `json` unmarshals mapped string holding embedded JSON, like
+// `{"a":1}`, into field of any type supported by json.Unmarshal, including
+// structs, maps and slices, so single form input can carry structured data.
+// Unmarshal errors are reported as BindingError.
+//
+// Binding `jsonarray` works like `json`, but can be used only for slice
+// fields, like []string or []int, which are bound from single mapped JSON
+// array, like `["a","b","c"]`, instead of splitting mapped string by
+// separator.
+//
+// Binding `urldecode` unescapes percent-encoded mapped string, like `a%20b`,
+// using url.QueryUnescape before passing it to binding specified as
+// argument, like `urldecode:int` (default binding for field type by
+// default). It's useful for hand-parsed query strings, since url.Values are
+// already unescaped. Malformed escapes are reported as BindingError.
+//
+// Binding `unmarshal` is used by default for fields of types which have no
+// other default binding and implement encoding.BinaryUnmarshaler or
+// encoding.TextUnmarshaler, like byte arrays or netip.Addr. Structs with
+// exported fields are still bound as nested, so binding should be specified
+// explicitly for them, like `binding:"unmarshal"`. Mapped []byte values
+// are passed to UnmarshalBinary and strings are passed to UnmarshalText,
+// falling back to other method if type implements only one of them. Strings
+// can be decoded into bytes, which are passed to UnmarshalBinary, using
+// `base64` or `hex` argument, like `unmarshal:base64`. Unmarshal errors are
+// reported as BindingError.
+//
+// Binding `append` binds fields of collection types which pointer has
+// `Add(T)` or `Append(T)` method, optionally returning error, like
+// `func (tags *Tags) Add(tag string)`. It should be specified explicitly,
+// like `binding:"append"`, so structs which happen to have such methods are
+// still bound as nested structs. Mapped string is split like for
+// slices (by `,` unless `sep` option is given) and every element is parsed
+// using binding specified as argument, like `append:int;sep=|`, or default
+// binding of T, and added into new collection. Elements of interface{} type
+// are added as strings. Errors of elements, including ones returned by the
+// method, are reported with index, like `Tags[1]`. Since *list.List has no
+// such method, it should be wrapped, like `type List struct{ list.List }`
+// with `Add(string)` calling PushBack. Collections which can't be copied,
+// like list.List, should be bound into pointer fields, like `Items *List`.
+//
+// Binding `error` converts mapped string into error using errors.New and
+// can be used for error fields. Fields of interface types, like error or
+// interface{}, are skipped unless binding is specified for them.
+//
+// Binding `flags` converts list of names into bit mask using mapping
+// specified in the form of `flags:<name>=<bit>|<name>=<bit>|...`, like
+// `flags:read=1|write=2|delete=4`. Mapped value can be either
+// comma-separated string or []string. Field can be of any integer type, but
+// bits should fit into it.
+//
+// Binding `uuidbytes` parses canonical UUID string like
+// `123e4567-e89b-12d3-a456-426614174000` into 16 bytes and can be used for
+// []byte and [16]byte fields.
+//
+// Binding `email` parses mapped value using mail.ParseAddress and is used
+// for mail.Address fields by default, so display name is captured as well,
+// like for `John <john@example.com>`. Option `strict` rejects addresses with
+// display names and option `address` makes binding produce address as
+// string, like `binding:"email:strict,address"` for string fields.
+//
+// Binding `phone` strips formatting characters, like spaces, dashes, dots and
+// parens, from phone number and checks that it has from 7 to 15 digits
+// (limits can be changed like `phone:10,11`), optionally prefixed with `+`,
+// like `+15551234567`. To normalize numbers further, like to E.164 format
+// using full-featured phone library, pass `PhoneNormalizer(<func>)`, which
+// receives cleaned number.
+//
+// Binding `header` can be used for map fields, like map[string][]string or
+// http.Header, to canonicalize keys using textproto.CanonicalMIMEHeaderKey.
+// Values of keys which are equal after canonicalization, like `content-type`
+// and `Content-Type`, are merged for slice values. Values are not parsed.
+//
+// # Field types
+//
+// Pointer fields are allocated only if mapper returns value for them, so
+// `*bool` field can be used to distinguish absent value from `false`.
+// Likewise, empty string is bound into `*string` field as pointer to empty
+// string, while absent value leaves it nil (unless EmptyAsAbsent is set).
+//
+// Slice fields are bound element by element: mapped value can be either
+// separated string or []string, and every element is parsed using binding
+// specified for the field with it's options (or default binding for slice
+// element type), like `binding:"time:2006-01-02;sep=,"` for []time.Time.
+// Binding errors of elements are reported with element index, like
+// `Tags[2]`.
+//
+// Separator is comma by default and can be changed for all slice fields by
+// passing `SliceSeparator("<separator>")` option or for specific field by
+// adding `sep=<separator>` as last option of `binding` tag, like
+// `binding:"int;sep=;"` or `binding:"int:8;sep=|"`. Tag option takes
+// precedence over SliceSeparator option.
+//
+// Map fields with string or int keys are bound from maps with string keys
+// returned by mapper, like map[string]string, map[string][]string or
+// url.Values. Keys of int maps are parsed as base 10 ints. Every map value is
+// parsed using binding specified for the field (or default binding for map
+// value type); map values of slice type are bound element by element.
+// Binding errors are reported with map key, like `Filters[color]`. Entries
+// which were bound successfully are set even if other entries fail.
+//
+// Map fields with string keys and interface values, like
+// map[string]interface{}, which have no `binding` tag, are passthrough: map
+// returned by mapper is copied as is, so values keep their native types, like
+// float64 or nested maps decoded from JSON. No binding runs for values of
+// such maps, so they are not validated in any way.
+//
+// Struct fields (and pointers to structs) without binding are bound
+// recursively. By default, mapper is called with dotted names for nested
+// fields, like `Address.City`. If `NestedMaps(true)` option is passed, mapper
+// is called with `Address` name instead and should return
+// map[string]interface{} with values for nested fields. Pointers to structs
+// are allocated only if at least one nested field has mapped value.
+//
+// Nested struct is considered present if at least one of it's fields has
+// mapped value. Required nested fields are checked only for present nested
+// structs, with errors reported using dotted names, like `Address.City`. If
+// nested struct field itself is required and absent, RequiredError is
+// reported for it, like `Address`.
+//
+// Slices of structs (or pointers to structs) without binding are bound
+// element by element using indexed names, like `Items[0].Name`, or from
+// []interface{} of maps returned by mapper for `Items` if `NestedMaps(true)`
+// is passed. Binding stops at first absent element; to allow gaps between
+// elements, pass `SliceGaps(<n>)`, which specifies how many consecutive
+// absent elements are tolerated. Gaps are left as nil pointers (or zero
+// structs) in resulting slice.
+//
+// Maps of structs (or pointers to structs) with string or int keys, like
+// map[string]ServiceConfig, are bound from maps returned by mapper, like
+// map[string]map[string]interface{}, where every value holds values for
+// fields of nested struct. Binding errors are reported with map key, like
+// `Services[web].Port`. Entries which fail to bind are not set, but other
+// entries are; to leave whole map unchanged instead, pass
+// `TransactionalMaps(true)`. With `ZeroOnError(true)` such maps are reset
+// to nil, regardless of TransactionalMaps.
+//
+// Interface fields with `polymorphic` binding, like
+// `binding:"polymorphic:Type"`, are bound as nested structs of concrete type
+// which name is held by sibling string field, like `Type`. Concrete types are
+// instantiated using constructors passed as
+// `PolymorphicTypes{"<name>": <constructor>}`. Such fields are bound after
+// all other fields of the struct. Unknown type names are reported as
+// BindingError, and empty type name means that field is absent.
+//
+// Fields of atomic.Int32, atomic.Int64, atomic.Uint32, atomic.Uint64,
+// atomic.Bool and atomic.Value types (or pointers to them) are set using
+// their Store method, so they can be bound while being read concurrently.
+// Values of atomic.Value fields are bound as strings unless field has
+// `binding` tag; note, that atomic.Value panics if values of different
+// types are stored. Such fields should be exported.
+//
+// # Options
+//
+// Bind accepts options as variadic arguments after mapper function. Options
+// are values of option types, which are documented separately.
+//
+// To specify binding functions, pass functions in the form of
+// `Bindings{"<name>": <function>}` or `WithBinding("<name>", <function>)`.
+// To specify binding functions which bind whole field and have access to
+// mapper, pass `FieldBindings{"<name>": <function>}`.
+//
+// To specify binding functions for fields of specific types, like
+// instantiations of generic types, pass
+// `TypeBindings{reflect.TypeOf(<value>): <function>}`. Such bindings are used
+// for fields of that type (as well as pointers, slices and maps of that type)
+// which have no `binding` tag, and take precedence over default bindings.
+// Structs with type binding are not bound recursively.
+//
+// To make binding available under another name, pass
+// `Aliases{"<alias>": "<name>"}`.
+//
+// To specify function that maps field to it's name, specify it as
+// `FieldNameFunc(<func>)`. It overrides function set by
+// SetDefaultFieldNameFunc.
+//
+// To treat fields with custom binding specified in `binding` tag as
+// required, pass `RequireTaggedBindings(true)`.
+//
+// To decide which fields are required using something other than `required`
+// tag, pass `RequiredFunc(<func>)`.
+//
+// To bind only some fields, pass `FieldFilter(<func>)`: fields for which
+// function returns false are skipped entirely.
+//
+// To prepend prefix to every name passed to mapper, pass
+// `Prefix("<prefix>")`. To transform names passed to mapper in other ways,
+// pass `KeyFunc(<func>)`. Names are resolved in following order: `from` tag
+// or FieldNameFunc, then Prefix, then KeyFunc, then KeyTransform, which is
+// usually passed to NewDecoder, and then result is looked up in Values
+// option and passed to mapper. Errors still use names returned by `from` tag
+// or FieldNameFunc.
+//
+// To specify defaults outside of struct definition, like
+// environment-specific ones, pass `Defaults{"<name>": <value>}`, keyed by
+// names which are used in errors, like `Database.Host`. Defaults are used if
+// mapper returns nil and take precedence over `default` tags. String values
+// (and []string) are parsed using field's binding, while values of other
+// types are set as is, so they should be assignable to the field.
+//
+// To read mapped values from in-memory map instead of calling mapper for
+// every field, pass `Values(<map>)`; mapper is then called only for names
+// which are absent in the map and can be nil.
+//
+// To bind fields which have binding that is not registered using default
+// binding for the field type (or `string` if there is none) instead of
+// returning InvalidBindingError, pass `IgnoreUnknownBindings(true)`.
+//
+// To skip fields which have no binding at all, like fields of types from
+// optional dependencies, instead of returning InvalidBindingError, pass
+// `SkipUnbindable(true)`. Fields with `binding` tag are never skipped.
+//
+// To report InvalidBindingError for fields which get empty name from
+// FieldNameFunc (except fields explicitly skipped with `-` name), pass
+// `StrictNames(true)`.
+//
+// To report InvalidBindingError if output struct has no exported fields
+// which can be bound, pass `RequireBindableFields(true)`.
+//
+// To treat empty strings returned by mapper as absent values, pass
+// `EmptyAsAbsent(true)`.
+//
+// To keep current values of fields which mapped values are parsed as zero
+// values, like `0` or empty string, pass `SkipZero(true)`. Note, that zero
+// values are still considered present, so required fields are not reported.
+// Fields which types implement Emptier are skipped if IsEmpty returns true,
+// regardless of reflect.Value.IsZero.
+//
+// To reset fields which fail to bind to zero values instead of leaving them
+// unchanged, pass `ZeroOnError(true)`.
+//
+// To compare bound values of two fields, like `Start < End` or
+// `Password == Confirm`, pass `Compare{{"<left>", "<operator>", "<right>"}}`.
+// Comparisons are checked after all fields are bound and ComparisonError is
+// reported for the left field if comparison fails.
+//
+// To stop binding after first field which fails to bind, pass
+// `FailFast(true)`.
+//
+// To limit number of reported errors, pass `MaxErrors(<n>)`: binding stops
+// when limit is reached and TruncatedError is added to the end of errors.
+//
+// To reuse backing arrays of already allocated slice fields, pass
+// `ReuseSlices(true)`.
+package binding
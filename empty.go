@@ -0,0 +1,36 @@
+package binding
+
+import (
+	"reflect"
+)
+
+// Emptier can be implemented by field types which zero value isn't the
+// logical empty value, like time.Time wrapper which uses specific date as
+// empty sentinel. If field type implements Emptier, IsEmpty is consulted
+// instead of reflect.Value.IsZero by SkipZero option and by Unbind for
+// fields with `omitempty` option.
+type Emptier interface {
+	IsEmpty() bool
+}
+
+var emptierType = reflect.TypeOf((*Emptier)(nil)).Elem()
+
+// isEmpty reports whether value is empty using Emptier if value (or pointer
+// to addressable value) implements it, or reflect.Value.IsZero otherwise.
+// Nil pointers are always empty.
+func isEmpty(value reflect.Value) bool {
+	if value.Kind() == reflect.Ptr && value.IsNil() {
+		return true
+	}
+
+	if value.CanInterface() && value.Type().Implements(emptierType) {
+		return value.Interface().(Emptier).IsEmpty()
+	}
+
+	if value.CanAddr() && value.Addr().CanInterface() &&
+		value.Addr().Type().Implements(emptierType) {
+		return value.Addr().Interface().(Emptier).IsEmpty()
+	}
+
+	return value.IsZero()
+}
@@ -2,6 +2,7 @@ package binding_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/seletskiy/binding-go"
@@ -103,3 +104,27 @@ func Example_perFieldErrors() {
 	// Name Error: binding.RequiredError
 	// Height Error: <nil>
 }
+
+func Example_phoneNormalizer() {
+	var contact struct {
+		Phone string `binding:"phone"`
+	}
+
+	// normalize local numbers to E.164 format
+	normalize := func(phone string) (string, error) {
+		if !strings.HasPrefix(phone, "+") {
+			phone = "+1" + phone
+		}
+
+		return phone, nil
+	}
+
+	binding.Bind(&contact, func(key string) interface{} {
+		return "(555) 123-4567"
+	}, binding.PhoneNormalizer(normalize))
+
+	fmt.Printf("Phone: %s\n", contact.Phone)
+
+	// Output:
+	// Phone: +15551234567
+}
@@ -0,0 +1,380 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Field describes struct field which is bound using FieldBindFunc.
+type Field struct {
+	// Name is a name of the field which is used in errors, like
+	// `Event.StartsAt`.
+	Name string
+
+	// StructField is a reflect description of the field.
+	StructField reflect.StructField
+
+	// Value is a value returned by mapper for the field, which can be nil.
+	Value interface{}
+
+	// Mapper is a mapper which can be used to obtain values of other keys
+	// from the same level of struct as the field.
+	Mapper MapFunc
+
+	// Bindings are registered bindings, which can be used to parse parts of
+	// the value.
+	Bindings Bindings
+}
+
+// FieldBindFunc is a binding function which binds whole field and has
+// access to the field description and mapper, so it can combine values of
+// several keys or produce value depending on field type.
+//
+// Second argument is optional argument string which is specified after `:`
+// char in the `binding` tag, like for BindFunc.
+//
+// Returned value is set into the field as is (pointers are allocated), so it
+// should be of the field type. Nil value with nil error means that field has
+// no value, so it's checked for being required. Returned BindingErrors are
+// reported as is, so errors for parts of the field can be reported, like
+// `Labels[env]`.
+type FieldBindFunc func(field Field, opts string) (interface{}, error)
+
+// FieldBindings is a map of field binding functions to it's name in
+// `binding` tag. Field bindings take precedence over Bindings with the same
+// name.
+type FieldBindings map[string]FieldBindFunc
+
+// bindField binds field with given index using field binding.
+func (binder *binder) bindField(
+	structValue reflect.Value,
+	index int,
+	mapper MapFunc,
+	key string,
+	name string,
+	binding FieldBindFunc,
+) (bool, error) {
+	var (
+		structType = structValue.Type()
+		field      = structType.Field(index)
+		data       = mapper(key)
+	)
+
+	_, opts := parseBindingTag(field)
+
+	if data == "" && binder.config.emptyAsAbsent {
+		data = nil
+	}
+
+	value, err := binding(
+		Field{
+			Name:        name,
+			StructField: field,
+			Value:       data,
+			Mapper:      mapper,
+			Bindings:    binder.config.bindings,
+		},
+		opts,
+	)
+	if err, ok := err.(InvalidBindingError); ok {
+		return false, err
+	}
+
+	if err != nil {
+		if errors, ok := err.(BindingErrors); ok {
+			binder.addErrors(name, errors)
+		} else {
+			binder.addError(name, err)
+		}
+
+		if binder.config.zeroOnError && structValue.Field(index).CanSet() {
+			structField := structValue.Field(index)
+			structField.Set(reflect.Zero(structField.Type()))
+		}
+
+		return true, nil
+	}
+
+	if value == nil {
+		if binder.config.isRequired(field) ||
+			binder.config.requireTaggedBindings {
+			binder.addError(name, RequiredError{name: name})
+		}
+
+		return false, nil
+	}
+
+	structField := structValue.Field(index)
+	if !structField.CanSet() {
+		return false, InvalidBindingError(
+			fmt.Sprintf(
+				`field %s.%s is unexported and can not be set`,
+				structType.Name(),
+				field.Name,
+			),
+		)
+	}
+
+	target := reflect.New(structField.Type()).Elem()
+	if !setValue(target, value) {
+		return false, InvalidBindingError(
+			fmt.Sprintf(
+				`binding of %s.%s returned %T, which can't be set`,
+				structType,
+				field.Name,
+				value,
+			),
+		)
+	}
+
+	if binder.report != nil {
+		binder.report.Values[name] = target.Interface()
+		binder.report.Provenance[name] = Provenance{
+			Name:     name,
+			RawValue: data,
+			Binding:  getBindingSpec(field),
+		}
+	}
+
+	if err := validateField(field, name, target); err != nil {
+		binder.addError(name, err)
+
+		if binder.config.zeroOnError {
+			structField.Set(reflect.Zero(structField.Type()))
+		}
+
+		return true, nil
+	}
+
+	binder.capture(name, target)
+
+	structField.Set(target)
+
+	return true, nil
+}
+
+// bindDateTime combines values of two keys, which hold date and time, into
+// time.Time. Keys are specified by `date` and `time` options, like
+// `datetime:date=event_date,time=event_time`. Options can be separated by
+// `,` or `;`.
+func bindDateTime(field Field, opts string) (interface{}, error) {
+	options := map[string]string{}
+
+	for _, option := range strings.FieldsFunc(opts, func(char rune) bool {
+		return char == ',' || char == ';'
+	}) {
+		pair := strings.SplitN(option, "=", 2)
+		if len(pair) != 2 {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("invalid datetime option: %q", option),
+			)
+		}
+
+		options[pair[0]] = pair[1]
+	}
+
+	if options["date"] == "" || options["time"] == "" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"datetime binding of %s requires date and time keys",
+				field.Name,
+			),
+		)
+	}
+
+	location := time.UTC
+
+	if tz, ok := options["tz"]; ok {
+		var err error
+
+		location, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("unknown time zone: %q", tz),
+			)
+		}
+	}
+
+	date, _ := field.Mapper(options["date"]).(string)
+	clock, _ := field.Mapper(options["time"]).(string)
+
+	switch {
+	case date == "" && clock == "":
+		return nil, nil
+	case date == "":
+		return nil, fmt.Errorf("date is missing")
+	case clock == "":
+		return nil, fmt.Errorf("time is missing")
+	}
+
+	layouts := []string{"2006-01-02 15:04", "2006-01-02 15:04:05"}
+	if layout, ok := options["layout"]; ok {
+		layouts = []string{layout}
+	}
+
+	var err error
+
+	for _, layout := range layouts {
+		var result time.Time
+
+		result, err = time.ParseInLocation(layout, date+" "+clock, location)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, err
+}
+
+// bindKeyValues parses string of key-value pairs, like `a=1,b=2`, into map
+// field. Positional option specifies binding of values (default binding for
+// map value type is used if it's empty), `pair` option specifies separator
+// of pairs (`,` by default) and `kv` option specifies separator of key and
+// value (`=` by default), like `kv:int;pair=;;kv=:`. Since separators can
+// contain `;`, options should be specified in that order.
+func bindKeyValues(field Field, opts string) (interface{}, error) {
+	mapType := field.StructField.Type
+	if mapType.Kind() == reflect.Ptr {
+		mapType = mapType.Elem()
+	}
+
+	if mapType.Kind() != reflect.Map {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("kv binding of %s requires map field", field.Name),
+		)
+	}
+
+	keyBinding, ok := getMapKeyBinding(mapType.Key())
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding into map with %s keys (%s) is not supported`,
+				mapType.Key(),
+				field.Name,
+			),
+		)
+	}
+
+	var (
+		spec          = opts
+		pairSeparator = ","
+		kvSeparator   = "="
+	)
+
+	if index := strings.Index(spec, ";kv="); index >= 0 {
+		spec, kvSeparator = spec[:index], spec[index+len(";kv="):]
+	}
+
+	if index := strings.Index(spec, ";pair="); index >= 0 {
+		spec, pairSeparator = spec[:index], spec[index+len(";pair="):]
+	}
+
+	if spec == "" {
+		spec = getDefaultBindingTag(getBindingType(mapType.Elem()))
+	}
+
+	if spec == "" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"binding for values of %s is not specified",
+				field.Name,
+			),
+		)
+	}
+
+	elemName, elemOpts := parseBindingTag(reflect.StructField{
+		Tag: reflect.StructTag(`binding:` + strconv.Quote(spec)),
+	})
+
+	binding, ok := field.Bindings[elemName]
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"binding %q for values of %s is not registered",
+				elemName,
+				field.Name,
+			),
+		)
+	}
+
+	if field.Value == nil {
+		return nil, nil
+	}
+
+	data, ok := field.Value.(string)
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"only strings are supported, but %T given",
+				field.Value,
+			),
+		)
+	}
+
+	var (
+		result = reflect.MakeMap(mapType)
+		errors BindingErrors
+	)
+
+	for _, pair := range strings.Split(data, pairSeparator) {
+		if pair == "" {
+			continue
+		}
+
+		parts := strings.SplitN(pair, kvSeparator, 2)
+		name := fmt.Sprintf("%s[%s]", field.Name, parts[0])
+
+		if len(parts) != 2 {
+			errors = append(errors, BindingError{
+				name:  name,
+				cause: fmt.Errorf("invalid key-value pair: %q", pair),
+			})
+
+			continue
+		}
+
+		key, err := keyBinding(parts[0], "")
+		if err != nil {
+			errors = append(errors, BindingError{name: name, cause: err})
+
+			continue
+		}
+
+		value, err := binding(parts[1], elemOpts)
+		if _, ok := err.(InvalidBindingError); ok {
+			return nil, err
+		}
+
+		if err != nil {
+			errors = append(errors, BindingError{name: name, cause: err})
+
+			continue
+		}
+
+		var (
+			mapKey   = reflect.New(mapType.Key()).Elem()
+			mapValue = reflect.New(mapType.Elem()).Elem()
+		)
+
+		if !setValue(mapKey, key) || !setValue(mapValue, value) {
+			return nil, InvalidBindingError(
+				fmt.Sprintf(
+					`binding of %s returned %T, which can't be set`,
+					name,
+					value,
+				),
+			)
+		}
+
+		result.SetMapIndex(mapKey, mapValue)
+	}
+
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	return result.Interface(), nil
+}
@@ -0,0 +1,123 @@
+package binding
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isoDurationUnits maps designators of ISO 8601 duration to durations of
+// units. Years and months have no fixed length, so they are approximated.
+var isoDurationUnits = map[bool]map[byte]time.Duration{
+	false: {
+		'Y': 365 * 24 * time.Hour,
+		'M': 30 * 24 * time.Hour,
+		'W': 7 * 24 * time.Hour,
+		'D': 24 * time.Hour,
+	},
+	true: {
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+	},
+}
+
+// bindISODuration parses ISO 8601 duration, like `P1DT2H30M`, into
+// time.Duration. Years and months are rejected unless `approximate` option
+// is given, like `isoduration:approximate`.
+func bindISODuration(data interface{}, opts string) (interface{}, error) {
+	if opts != "" && opts != "approximate" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("unknown isoduration option: %q", opts),
+		)
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	return parseISODuration(data.(string), opts == "approximate")
+}
+
+func parseISODuration(value string, approximate bool) (time.Duration, error) {
+	var (
+		invalid = fmt.Errorf("invalid ISO 8601 duration: %q", value)
+		rest    = value
+		sign    = 1.0
+		total   = 0.0
+		inTime  = false
+		order   = "YMWD"
+	)
+
+	switch {
+	case strings.HasPrefix(rest, "-"):
+		sign = -1
+		rest = rest[1:]
+	case strings.HasPrefix(rest, "+"):
+		rest = rest[1:]
+	}
+
+	if !strings.HasPrefix(rest, "P") || len(rest) == 1 {
+		return 0, invalid
+	}
+
+	rest = rest[1:]
+
+	for rest != "" {
+		if rest[0] == 'T' {
+			if inTime || len(rest) == 1 {
+				return 0, invalid
+			}
+
+			inTime = true
+			order = "HMS"
+			rest = rest[1:]
+
+			continue
+		}
+
+		end := strings.IndexFunc(rest, func(char rune) bool {
+			return (char < '0' || char > '9') && char != '.' && char != ','
+		})
+		if end <= 0 {
+			return 0, invalid
+		}
+
+		number, err := strconv.ParseFloat(
+			strings.Replace(rest[:end], ",", ".", 1),
+			64,
+		)
+		if err != nil {
+			return 0, invalid
+		}
+
+		designator := rest[end]
+
+		index := strings.IndexByte(order, designator)
+		if index < 0 {
+			return 0, invalid
+		}
+
+		if !inTime && (designator == 'Y' || designator == 'M') && !approximate {
+			return 0, fmt.Errorf(
+				"years and months are not allowed in ISO 8601 duration: %q",
+				value,
+			)
+		}
+
+		total += number * float64(isoDurationUnits[inTime][designator])
+		order = order[index+1:]
+		rest = rest[end+1:]
+	}
+
+	// float64(math.MaxInt64) is rounded up to 2^63, so it's out of range.
+	if total >= math.MaxInt64 {
+		return 0, fmt.Errorf("ISO 8601 duration is out of range: %q", value)
+	}
+
+	return time.Duration(math.Round(sign * total)), nil
+}
@@ -0,0 +1,55 @@
+package binding
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// bindJSON unmarshals mapped JSON string, like `{"a":1}`, into new value of
+// the field type using json.Unmarshal, so field can be of any type which
+// encoding/json supports, including structs.
+func bindJSON(field Field, _ string) (interface{}, error) {
+	if field.Value == nil {
+		return nil, nil
+	}
+
+	data, ok := field.Value.(string)
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"only strings are supported, but %T given",
+				field.Value,
+			),
+		)
+	}
+
+	target := reflect.New(field.StructField.Type)
+
+	err := json.Unmarshal([]byte(data), target.Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	return target.Elem().Interface(), nil
+}
+
+// bindJSONArray works like bindJSON, but requires field to be a slice (or
+// pointer to slice), so mapped value should be JSON array, like `["a","b"]`.
+func bindJSONArray(field Field, opts string) (interface{}, error) {
+	fieldType := field.StructField.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Slice {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"jsonarray binding of %s requires slice field",
+				field.Name,
+			),
+		)
+	}
+
+	return bindJSON(field, opts)
+}
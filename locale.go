@@ -0,0 +1,82 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale describes formatting of numbers which can be used in `locale`
+// option of `int` and `float` bindings, like `float:64;locale=de`.
+type Locale struct {
+	// Thousands lists separators of digit groups, which are stripped
+	// before parsing.
+	Thousands []string
+
+	// Decimal is a decimal point, which is replaced by `.` before parsing.
+	Decimal string
+}
+
+// Locales option specifies additional locales which can be used in `locale`
+// option of `int` and `float` bindings, like `Locales{"in": {...}}`.
+// Built-in locales are `en` (1,234.56), `de` (1.234,56), `fr` (1 234,56) and
+// `ch` (1'234.56); they can be overridden.
+type Locales map[string]Locale
+
+var builtinLocales = Locales{
+	"en": {Thousands: []string{","}, Decimal: "."},
+	"de": {Thousands: []string{"."}, Decimal: ","},
+	"fr": {Thousands: []string{" ", " ", " "}, Decimal: ","},
+	"ch": {Thousands: []string{"'", "’"}, Decimal: "."},
+}
+
+// bindInt is a built-in `int` binding, which can use locales specified by
+// Locales option.
+func (config *config) bindInt(
+	data interface{},
+	opts string,
+) (interface{}, error) {
+	return bindLocalizedInt(data, opts, config.locales)
+}
+
+// bindFloat is a built-in `float` binding, which can use locales specified
+// by Locales option.
+func (config *config) bindFloat(
+	data interface{},
+	opts string,
+) (interface{}, error) {
+	return bindLocalizedFloat(data, opts, config.locales)
+}
+
+// normalizeNumber converts number formatted according to locale with given
+// name into format accepted by strconv. Locale is looked up in given
+// locales first and then in built-in ones. Empty name means no conversion.
+func normalizeNumber(
+	value string,
+	name string,
+	locales Locales,
+) (string, error) {
+	if name == "" {
+		return value, nil
+	}
+
+	locale, ok := locales[name]
+	if !ok {
+		locale, ok = builtinLocales[name]
+	}
+
+	if !ok {
+		return "", InvalidBindingError(
+			fmt.Sprintf("unknown locale: %q", name),
+		)
+	}
+
+	for _, separator := range locale.Thousands {
+		value = strings.Replace(value, separator, "", -1)
+	}
+
+	if locale.Decimal != "" && locale.Decimal != "." {
+		value = strings.Replace(value, locale.Decimal, ".", -1)
+	}
+
+	return value, nil
+}
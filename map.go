@@ -0,0 +1,237 @@
+package binding
+
+import (
+	"fmt"
+	"net/textproto"
+	"reflect"
+	"sort"
+)
+
+func isMapType(fieldType reflect.Type) bool {
+	return fieldType.Kind() == reflect.Map
+}
+
+func bindMap(
+	target reflect.Value,
+	name string,
+	data interface{},
+	binding func(interface{}) (interface{}, error),
+	separator string,
+	canonicalKeys bool,
+) (BindingErrors, error) {
+	source := reflect.ValueOf(data)
+	if source.Kind() != reflect.Map ||
+		source.Type().Key().Kind() != reflect.String {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding values of type %T (%s) into map is not supported`,
+				data,
+				name,
+			),
+		)
+	}
+
+	keyBinding, ok := getMapKeyBinding(target.Type().Key())
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding into map with %s keys (%s) is not supported`,
+				target.Type().Key(),
+				name,
+			),
+		)
+	}
+
+	var (
+		result    = reflect.MakeMap(target.Type())
+		valueType = target.Type().Elem()
+		keys      = source.MapKeys()
+		errors    BindingErrors
+	)
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+
+	for _, key := range keys {
+		rawKey := key.String()
+		if canonicalKeys {
+			rawKey = textproto.CanonicalMIMEHeaderKey(rawKey)
+		}
+
+		var (
+			raw       = source.MapIndex(key).Interface()
+			value     = reflect.New(valueType).Elem()
+			valueName = fmt.Sprintf("%s[%s]", name, rawKey)
+			mapKey    = reflect.New(target.Type().Key()).Elem()
+		)
+
+		boundKey, err := keyBinding(rawKey, "")
+		if err != nil {
+			errors = append(errors, BindingError{
+				name:  valueName,
+				cause: err,
+			})
+
+			continue
+		}
+
+		setValue(mapKey, boundKey)
+
+		if isSliceType(valueType) {
+			items, ok := getSliceItems(raw, separator)
+			if !ok {
+				return nil, InvalidBindingError(
+					fmt.Sprintf(
+						`binding values of type %T (%s) is not supported`,
+						raw,
+						valueName,
+					),
+				)
+			}
+
+			sliceErrors, err := bindSlice(
+				value,
+				valueName,
+				items,
+				binding,
+				false,
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(sliceErrors) > 0 {
+				errors = append(errors, sliceErrors...)
+
+				continue
+			}
+		} else {
+			if _, ok := raw.(string); !ok {
+				return nil, InvalidBindingError(
+					fmt.Sprintf(
+						`binding values of type %T (%s) is not supported`,
+						raw,
+						valueName,
+					),
+				)
+			}
+
+			bound, err := binding(raw.(string))
+			if err, ok := err.(InvalidBindingError); ok {
+				return nil, err
+			}
+
+			if err != nil {
+				errors = append(errors, BindingError{
+					name:  valueName,
+					cause: err,
+				})
+
+				continue
+			}
+
+			if !setValue(value, bound) {
+				return nil, InvalidBindingError(
+					fmt.Sprintf(
+						`binding of %s returned %T, which can't be set`,
+						valueName,
+						bound,
+					),
+				)
+			}
+		}
+
+		if existing := result.MapIndex(mapKey); existing.IsValid() &&
+			canonicalKeys && isSliceType(valueType) {
+			value = reflect.AppendSlice(existing, value)
+		}
+
+		result.SetMapIndex(mapKey, value)
+	}
+
+	target.Set(result)
+
+	return errors, nil
+}
+
+// isPassthroughMapType reports whether field is a map with string keys and
+// interface values, like map[string]interface{}, which has no `binding` tag.
+// Mapped values are copied into such maps as is.
+func isPassthroughMapType(field reflect.StructField) bool {
+	return field.Type.Kind() == reflect.Map &&
+		field.Type.Key().Kind() == reflect.String &&
+		field.Type.Elem().Kind() == reflect.Interface &&
+		field.Tag.Get("binding") == ""
+}
+
+// bindPassthroughMap copies entries of mapped map into target without
+// running any binding, so values keep their native types, like float64 or
+// nested maps decoded from JSON.
+func bindPassthroughMap(
+	target reflect.Value,
+	name string,
+	data interface{},
+) error {
+	source := reflect.ValueOf(data)
+	if source.Kind() != reflect.Map ||
+		source.Type().Key().Kind() != reflect.String {
+		return InvalidBindingError(
+			fmt.Sprintf(
+				`binding values of type %T (%s) into map is not supported`,
+				data,
+				name,
+			),
+		)
+	}
+
+	var (
+		targetType = target.Type()
+		result     = reflect.MakeMapWithSize(targetType, source.Len())
+	)
+
+	for _, key := range source.MapKeys() {
+		var (
+			raw    = source.MapIndex(key).Interface()
+			value  = reflect.New(targetType.Elem()).Elem()
+			mapKey = reflect.New(targetType.Key()).Elem()
+		)
+
+		if !setValue(value, raw) {
+			return InvalidBindingError(
+				fmt.Sprintf(
+					`value of type %T (%s[%s]) can't be set into %s`,
+					raw,
+					name,
+					key.String(),
+					targetType.Elem(),
+				),
+			)
+		}
+
+		mapKey.SetString(key.String())
+
+		result.SetMapIndex(mapKey, value)
+	}
+
+	target.Set(result)
+
+	return nil
+}
+
+// getMapKeyBinding returns binding for map keys of given type. Only string
+// and int keys are supported.
+func getMapKeyBinding(keyType reflect.Type) (BindFunc, bool) {
+	switch keyType.Kind() {
+	case reflect.String:
+		return bindString, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, bits := parseBindingTag(reflect.StructField{Type: keyType})
+
+		return func(data interface{}, _ string) (interface{}, error) {
+			return bindInt(data, bits)
+		}, true
+	default:
+		return nil, false
+	}
+}
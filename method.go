@@ -0,0 +1,66 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// getMethodBinding returns binding which calls method of the struct with
+// given name. Method should have signature `func(string) (T, error)`, where T
+// is a type of value to be set into the field.
+func getMethodBinding(
+	structValue reflect.Value,
+	field reflect.StructField,
+	name string,
+) (func(interface{}) (interface{}, error), error) {
+	var method reflect.Value
+
+	if structValue.CanAddr() {
+		method = structValue.Addr().MethodByName(name)
+	} else {
+		method = structValue.MethodByName(name)
+	}
+
+	if !method.IsValid() {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding method %s of %s.%s is not found (is it exported?)`,
+				name,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	methodType := method.Type()
+	if methodType.NumIn() != 1 || methodType.In(0).Kind() != reflect.String ||
+		methodType.NumOut() != 2 || methodType.Out(1) != errorType {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding method %s of %s.%s should have signature `+
+					`func(string) (T, error)`,
+				name,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	return func(data interface{}) (interface{}, error) {
+		if _, ok := data.(string); !ok {
+			return nil, InvalidBindingError(
+				fmt.Sprintf("only strings are supported, but %T given", data),
+			)
+		}
+
+		results := method.Call([]reflect.Value{
+			reflect.ValueOf(data).Convert(methodType.In(0)),
+		})
+
+		if err := results[1]; !err.IsNil() {
+			return nil, err.Interface().(error)
+		}
+
+		return results[0].Interface(), nil
+	}, nil
+}
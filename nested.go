@@ -0,0 +1,461 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// isNestedType reports whether field should be bound as nested struct, which
+// is the case for struct fields (or pointers to structs) which have no
+// binding.
+func isNestedType(field reflect.StructField) bool {
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.Struct || isAtomicType(fieldType) {
+		return false
+	}
+
+	name, _ := parseBindingTag(field)
+
+	return name == ""
+}
+
+// bindNested binds nested struct field. If none of nested fields have
+// mapped values, nested struct is considered absent: errors about missing
+// required nested fields are discarded and RequiredError is reported for
+// the struct field itself if it's required.
+func (binder *binder) bindNested(
+	target reflect.Value,
+	nestedMapper MapFunc,
+	name string,
+	required bool,
+) (bool, error) {
+	var (
+		value  = target
+		errors = len(binder.errors)
+	)
+
+	if target.Kind() == reflect.Ptr {
+		value = reflect.New(target.Type().Elem())
+		if !target.IsNil() {
+			value.Elem().Set(target.Elem())
+		}
+	}
+
+	bound, err := binder.bindStruct(
+		reflect.Indirect(value),
+		nestedMapper,
+		name+".",
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if !bound {
+		binder.errors = binder.errors[:errors]
+
+		if required {
+			binder.addError(name, RequiredError{name: name})
+		}
+
+		return false, nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if !target.CanSet() {
+			return false, InvalidBindingError(
+				fmt.Sprintf(`field %s is unexported and can not be set`, name),
+			)
+		}
+
+		target.Set(value)
+	}
+
+	return true, nil
+}
+
+func (binder *binder) getNestedMapper(
+	mapper MapFunc,
+	key string,
+	name string,
+) (MapFunc, error) {
+	if !binder.config.nestedMaps {
+		return func(nestedKey string) interface{} {
+			return mapper(key + "." + nestedKey)
+		}, nil
+	}
+
+	return getMapMapper(mapper(key), name)
+}
+
+// getMapMapper returns mapper which obtains values from given nested map.
+func getMapMapper(data interface{}, name string) (MapFunc, error) {
+	switch data := data.(type) {
+	case nil:
+		return func(string) interface{} {
+			return nil
+		}, nil
+	case map[string]interface{}:
+		return func(nestedKey string) interface{} {
+			return data[nestedKey]
+		}, nil
+	default:
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				`binding values of type %T (%s) into struct is not supported`,
+				data,
+				name,
+			),
+		)
+	}
+}
+
+// maxNestedSliceItems is a maximal number of elements of nested slice.
+const maxNestedSliceItems = 10000
+
+// isNestedSliceType reports whether field is a slice of structs (or pointers
+// to structs) which have no binding, so elements should be bound as nested
+// structs.
+func isNestedSliceType(field reflect.StructField) bool {
+	if !isSliceType(field.Type) {
+		return false
+	}
+
+	elemType := field.Type.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct || isOpaqueType(elemType) {
+		return false
+	}
+
+	name, _ := parseBindingTag(field)
+
+	return name == ""
+}
+
+// bindNestedSlice binds slice of nested structs. Elements are obtained using
+// indexed names, like `Items[0].Name`, or from []interface{} returned by
+// mapper if NestedMaps option is set. Binding stops at first absent element
+// unless SliceGaps option allows to skip some absent elements, which are
+// left as nil (or zero structs) in resulting slice. Number of elements is
+// limited by `maxitems` tag or by maxNestedSliceItems, so mapper which
+// returns non-nil values for every key can't make binding endless.
+func (binder *binder) bindNestedSlice(
+	target reflect.Value,
+	field reflect.StructField,
+	mapper MapFunc,
+	key string,
+	name string,
+) (bool, error) {
+	minItems, maxItems, err := getItemsLimits(field)
+	if err != nil {
+		return false, err
+	}
+
+	if maxItems == 0 || maxItems > maxNestedSliceItems {
+		maxItems = maxNestedSliceItems
+	}
+
+	var (
+		elemType = target.Type().Elem()
+		slice    = reflect.MakeSlice(target.Type(), 0, 0)
+		gaps     = 0
+		bound    = false
+	)
+
+	getElementMapper := func(index int) (MapFunc, bool, error) {
+		return func(nestedKey string) interface{} {
+			return mapper(fmt.Sprintf("%s[%d].%s", key, index, nestedKey))
+		}, true, nil
+	}
+
+	if binder.config.nestedMaps {
+		data := reflect.ValueOf(mapper(key))
+		if data.IsValid() && data.Kind() != reflect.Slice {
+			return false, InvalidBindingError(
+				fmt.Sprintf(
+					`binding values of type %s (%s) into slice `+
+						`is not supported`,
+					data.Type(),
+					name,
+				),
+			)
+		}
+
+		getElementMapper = func(index int) (MapFunc, bool, error) {
+			if !data.IsValid() || index >= data.Len() {
+				return nil, false, nil
+			}
+
+			elemMapper, err := getMapMapper(
+				data.Index(index).Interface(),
+				fmt.Sprintf("%s[%d]", name, index),
+			)
+
+			return elemMapper, true, err
+		}
+	}
+
+	for index := 0; !binder.isStopped(); index++ {
+		elemMapper, ok, err := getElementMapper(index)
+		if err != nil {
+			return false, err
+		}
+
+		if !ok {
+			break
+		}
+
+		value := reflect.New(elemType).Elem()
+
+		elemBound, err := binder.bindNested(
+			value,
+			elemMapper,
+			fmt.Sprintf("%s[%d]", name, index),
+			false,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		if !elemBound {
+			gaps++
+			if gaps > binder.config.sliceGaps && !binder.config.nestedMaps {
+				break
+			}
+
+			continue
+		}
+
+		for ; gaps > 0; gaps-- {
+			slice = reflect.Append(slice, reflect.Zero(elemType))
+		}
+
+		slice = reflect.Append(slice, value)
+		bound = true
+
+		if slice.Len() > maxItems {
+			binder.addError(name, LengthError{
+				name:   name,
+				actual: slice.Len(),
+				min:    minItems,
+				max:    maxItems,
+			})
+
+			return false, nil
+		}
+	}
+
+	if !bound {
+		if binder.config.isRequired(field) {
+			binder.addError(name, RequiredError{name: name})
+		}
+
+		return false, nil
+	}
+
+	if slice.Len() < minItems {
+		binder.addError(name, LengthError{
+			name:   name,
+			actual: slice.Len(),
+			min:    minItems,
+			max:    maxItems,
+		})
+
+		return false, nil
+	}
+
+	if !target.CanSet() {
+		return false, InvalidBindingError(
+			fmt.Sprintf(`field %s is unexported and can not be set`, name),
+		)
+	}
+
+	target.Set(slice)
+
+	return true, nil
+}
+
+// isNestedMapType reports whether field is a map with string or int keys
+// and nested struct (or pointer to struct) values, which are bound
+// recursively.
+func isNestedMapType(field reflect.StructField) bool {
+	if !isMapType(field.Type) {
+		return false
+	}
+
+	if _, ok := getMapKeyBinding(field.Type.Key()); !ok {
+		return false
+	}
+
+	elemType := field.Type.Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct || isOpaqueType(elemType) {
+		return false
+	}
+
+	name, _ := parseBindingTag(field)
+
+	return name == ""
+}
+
+// bindNestedMap binds map of nested structs from map returned by mapper,
+// like map[string]map[string]interface{}, where every value holds values for
+// fields of nested struct. Errors are reported with map key, like
+// `Services[web].Port`. Entries which fail to bind are not set, and if
+// TransactionalMaps option is set, map is left unchanged at all, which is
+// also the case when binding is stopped by FailFast or MaxErrors.
+func (binder *binder) bindNestedMap(
+	target reflect.Value,
+	data interface{},
+	name string,
+	required bool,
+) (bool, error) {
+	if data == nil {
+		if required {
+			binder.addError(name, RequiredError{name: name})
+		}
+
+		return false, nil
+	}
+
+	source := reflect.ValueOf(data)
+	if source.Kind() != reflect.Map ||
+		source.Type().Key().Kind() != reflect.String {
+		return false, InvalidBindingError(
+			fmt.Sprintf(
+				`binding values of type %T (%s) into map is not supported`,
+				data,
+				name,
+			),
+		)
+	}
+
+	if !target.CanSet() {
+		return false, InvalidBindingError(
+			fmt.Sprintf(`field %s is unexported and can not be set`, name),
+		)
+	}
+
+	var (
+		result   = reflect.MakeMap(target.Type())
+		elemType = target.Type().Elem()
+		keys     = getNestedMapKeys(source, target.Type().Key())
+		failed   = false
+	)
+
+	for _, key := range keys {
+		if binder.isStopped() {
+			failed = true
+
+			break
+		}
+
+		var (
+			elemName = fmt.Sprintf("%s[%s]", name, key.source.String())
+			errors   = len(binder.errors)
+		)
+
+		if key.err != nil {
+			binder.addError(elemName, key.err)
+			failed = true
+
+			continue
+		}
+
+		elemMapper, err := getMapMapper(
+			source.MapIndex(key.source).Interface(),
+			elemName,
+		)
+		if err != nil {
+			return false, err
+		}
+
+		value := reflect.New(elemType).Elem()
+		if elemType.Kind() == reflect.Ptr {
+			value.Set(reflect.New(elemType.Elem()))
+		}
+
+		_, err = binder.bindNested(value, elemMapper, elemName, false)
+		if err != nil {
+			return false, err
+		}
+
+		if len(binder.errors) > errors {
+			failed = true
+
+			continue
+		}
+
+		result.SetMapIndex(key.value, value)
+	}
+
+	if failed && binder.config.zeroOnError {
+		target.Set(reflect.Zero(target.Type()))
+
+		return true, nil
+	}
+
+	if failed && binder.config.transactionalMaps {
+		return true, nil
+	}
+
+	target.Set(result)
+
+	return true, nil
+}
+
+// nestedMapKey is a key of map returned by mapper along with its value
+// bound to the key type of target map.
+type nestedMapKey struct {
+	source reflect.Value
+	value  reflect.Value
+	err    error
+}
+
+// getNestedMapKeys binds keys of source map to given key type and sorts
+// them, so entries are bound in stable order. Integer keys are sorted by
+// value, and keys which fail to bind come last.
+func getNestedMapKeys(
+	source reflect.Value,
+	keyType reflect.Type,
+) []nestedMapKey {
+	var (
+		keyBinding, _ = getMapKeyBinding(keyType)
+		keys          = []nestedMapKey{}
+	)
+
+	for _, key := range source.MapKeys() {
+		value := reflect.New(keyType).Elem()
+
+		boundKey, err := keyBinding(key.String(), "")
+		if err == nil {
+			setValue(value, boundKey)
+		}
+
+		keys = append(keys, nestedMapKey{source: key, value: value, err: err})
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i].err == nil) != (keys[j].err == nil) {
+			return keys[i].err == nil
+		}
+
+		if keys[i].err == nil && keyType.Kind() != reflect.String {
+			return keys[i].value.Int() < keys[j].value.Int()
+		}
+
+		return keys[i].source.String() < keys[j].source.String()
+	})
+
+	return keys
+}
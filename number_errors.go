@@ -0,0 +1,61 @@
+package binding
+
+import (
+	"strconv"
+)
+
+// SyntaxError is returned by built-in numeric bindings when mapped value is
+// not a number.
+type SyntaxError struct {
+	cause *strconv.NumError
+}
+
+// Value returns mapped value which failed to parse.
+func (err SyntaxError) Value() string {
+	return err.cause.Num
+}
+
+func (err SyntaxError) Error() string {
+	return err.cause.Error()
+}
+
+func (err SyntaxError) Unwrap() error {
+	return err.cause
+}
+
+// RangeError is returned by built-in numeric bindings when mapped value is
+// a number, but it's out of range for the field type.
+type RangeError struct {
+	cause *strconv.NumError
+}
+
+// Value returns mapped value which failed to parse.
+func (err RangeError) Value() string {
+	return err.cause.Num
+}
+
+func (err RangeError) Error() string {
+	return err.cause.Error()
+}
+
+func (err RangeError) Unwrap() error {
+	return err.cause
+}
+
+// wrapNumError converts error returned by strconv into SyntaxError or
+// RangeError if possible.
+func wrapNumError(err error) error {
+	numError, ok := err.(*strconv.NumError)
+	if !ok {
+		return err
+	}
+
+	switch numError.Err {
+	case strconv.ErrSyntax:
+		return SyntaxError{cause: numError}
+	case strconv.ErrRange:
+		return RangeError{cause: numError}
+	default:
+		return err
+	}
+}
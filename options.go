@@ -0,0 +1,503 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ReuseSlices option, when set to true, makes Bind to reuse backing array of
+// already allocated slice field if it has enough capacity instead of
+// allocating new slice. Note, that elements of existing slice will be
+// overwritten even if binding of some elements fails.
+type ReuseSlices bool
+
+// SliceSeparator option specifies separator which is used to split mapped
+// string values of all slice fields. It's comma by default. Separator
+// specified in `binding` tag of the field takes precedence.
+type SliceSeparator string
+
+// RequireTaggedBindings option, when set to true, makes Bind to treat every
+// field which has custom binding specified in `binding` tag as required, so
+// RequiredError will be reported if mapper returns no value for it.
+type RequireTaggedBindings bool
+
+// FailFast option, when set to true, makes Bind to stop after first field
+// which fails to bind and return only errors for this field.
+type FailFast bool
+
+// NestedMaps option, when set to true, makes Bind to obtain values for
+// nested struct fields from map[string]interface{} returned by mapper for
+// the struct field itself, like `{"Address": {"City": "NYC"}}`. Otherwise,
+// mapper is called with dotted keys, like `Address.City`.
+type NestedMaps bool
+
+// FieldFilter option specifies function which decides whether field should
+// be bound at all. Fields for which it returns false are skipped like fields
+// with empty name: they are neither bound nor validated.
+type FieldFilter func(field reflect.StructField) bool
+
+// Prefix option specifies prefix which is prepended to every name before
+// passing it to mapper.
+type Prefix string
+
+// KeyFunc option specifies function which transforms every name (after
+// Prefix is applied) just before passing it to mapper.
+type KeyFunc func(name string) string
+
+// RequireBindableFields option, when set to true, makes Bind to return
+// InvalidBindingError if output struct has no exported fields eligible for
+// binding, which usually means that wrong struct is passed.
+type RequireBindableFields bool
+
+// StrictNames option, when set to true, makes Bind to return
+// InvalidBindingError if FieldNameFunc returns empty name for a field, unless
+// field is explicitly skipped using `-` name, like `form:"-"`.
+type StrictNames bool
+
+// SliceGaps option specifies how many consecutive absent elements are
+// tolerated when binding slices of structs using indexed names, like
+// `Items[0].Name`. It's zero by default, so binding stops at first absent
+// element.
+type SliceGaps int
+
+// MaxErrors option limits number of errors which are collected by Bind. When
+// limit is reached, binding stops and TruncatedError is appended to errors.
+// Zero means no limit.
+type MaxErrors int
+
+// EmptyAsAbsent option, when set to true, makes Bind to treat empty strings
+// returned by mapper as absent values, so fields keep their values and
+// required fields are reported.
+type EmptyAsAbsent bool
+
+// SkipZero option, when set to true, makes Bind to leave fields intact if
+// their mapped values are parsed as zero values, like `0` for ints, which is
+// handy for merging sparse updates into existing struct. It's applied after
+// required check, so field with zero mapped value is still considered
+// present. Fields which types implement Emptier are checked using IsEmpty.
+type SkipZero bool
+
+// IgnoreUnknownBindings option, when set to true, makes Bind to use default
+// binding for the field type (or `string` binding if there is none) for
+// fields which have binding specified in `binding` tag that is not
+// registered, instead of returning InvalidBindingError. It's useful when
+// structs are shared between services which register different bindings.
+type IgnoreUnknownBindings bool
+
+// SkipUnbindable option, when set to true, makes Bind to skip fields which
+// have no binding at all (no `binding` tag, no type binding and no
+// registered default binding for field type) instead of returning
+// InvalidBindingError. Fields which have binding, but fail to bind, are
+// still reported.
+type SkipUnbindable bool
+
+// IgnoreUnknownArgs option, when set to true, makes BindArgs to skip flags
+// which don't match any field instead of reporting them as errors.
+type IgnoreUnknownArgs bool
+
+// TypeBindings option specifies binding functions for fields of given types,
+// like `TypeBindings{reflect.TypeOf(Nullable[int]{}): bindNullableInt}`.
+// Binding is used for fields which type (or element type of pointer, slice or
+// map) is equal to the key and which have no `binding` tag. Binding function
+// is called with empty options string.
+type TypeBindings map[reflect.Type]BindFunc
+
+// RequiredFunc option specifies function which decides whether field is
+// required instead of `required` tag, like function which also checks
+// `validate:"required"` tag.
+type RequiredFunc func(field reflect.StructField) bool
+
+// RequiredTagKey option specifies key of the tag which marks fields as
+// required instead of `required`, like `RequiredTagKey("validate")`. Tag
+// value should be either true bool or comma-separated list containing
+// `required`, like `validate:"required,email"`. RequiredFunc option takes
+// precedence.
+type RequiredTagKey string
+
+// ZeroOnError option, when set to true, makes Bind to set fields which fail
+// to bind or which values are rejected by `oneof` tag to zero values, so
+// struct doesn't carry stale values of such fields. Otherwise, such fields
+// are left unchanged. Fields bound using `setter` tag are never reset. Map
+// fields, including maps of nested structs, are reset as well, even though
+// they otherwise keep entries which are bound successfully. ZeroOnError
+// takes precedence over TransactionalMaps, so failed maps of nested structs
+// are reset instead of being left unchanged if both options are set.
+type ZeroOnError bool
+
+// Defaults option specifies default values of fields keyed by names which
+// are used in errors, like `Defaults{"Database.Host": "localhost"}`. Default
+// is used if mapper returns nil for the field and takes precedence over
+// `default` tag. Strings are parsed using field's binding, while values of
+// other types are set as is. Several Defaults options are merged.
+type Defaults map[string]interface{}
+
+// Values option specifies map which Bind reads mapped values from directly,
+// which is faster and simpler than mapper function for in-memory sources.
+// Mapper passed to Bind is called only for names which are absent in the
+// map and can be nil. Names are looked up after Prefix, KeyFunc and
+// KeyTransform options are applied. If several Values options are
+// passed, the last one is used.
+type Values map[string]interface{}
+
+// TransactionalMaps option, when set to true, makes Bind to leave maps of
+// nested structs unchanged if any of their entries fails to bind. Otherwise,
+// entries which are bound successfully are set. If ZeroOnError option is set
+// as well, it takes precedence and such maps are reset to nil.
+type TransactionalMaps bool
+
+// Aliases option specifies alternative names for bindings in the form of
+// `Aliases{"<alias>": "<binding>"}`, like `Aliases{"integer": "int"}`.
+// Aliases can refer to built-in bindings, including field bindings like
+// `json` or `kv`, bindings passed in options and other aliases. Binding
+// which refers to unknown binding or to itself (directly or through other
+// aliases) returns InvalidBindingError.
+type Aliases map[string]string
+
+// config is a set of options which are used by Bind.
+type config struct {
+	bindings         Bindings
+	fieldBindings    FieldBindings
+	typeBindings     TypeBindings
+	polymorphicTypes PolymorphicTypes
+	aliases          Aliases
+	fieldNameFunc    FieldNameFunc
+	reuseSlices      bool
+
+	sliceSeparator string
+	sliceGaps      int
+
+	requireTaggedBindings bool
+	failFast              bool
+	maxErrors             int
+	nestedMaps            bool
+	requireBindableFields bool
+	strictNames           bool
+	emptyAsAbsent         bool
+	skipZero              bool
+	ignoreUnknownBindings bool
+	skipUnbindable        bool
+	ignoreUnknownArgs     bool
+	zeroOnError           bool
+	transactionalMaps     bool
+
+	comparisons Compare
+
+	phoneNormalizer PhoneNormalizer
+	locales         Locales
+
+	fieldFilter    FieldFilter
+	requiredFunc   RequiredFunc
+	requiredTagKey string
+
+	prefix       string
+	keyFunc      KeyFunc
+	keyTransform KeyTransform
+
+	values   Values
+	defaults Defaults
+}
+
+func newConfig(options []interface{}) *config {
+	config := &config{
+		bindings: Bindings{
+			"complex": bindComplex,
+			"string":  bindString,
+			"bool":    bindBool,
+
+			"duration":    bindDuration,
+			"isoduration": bindISODuration,
+			"flags":       bindFlags,
+			"header":      bindString,
+			"time":        bindTime,
+			"rat":         bindRat,
+			"error":       bindError,
+			"email":       bindEmail,
+
+			"uuidbytes": bindUUIDBytes,
+		},
+		fieldBindings: FieldBindings{
+			"datetime":  bindDateTime,
+			"kv":        bindKeyValues,
+			"json":      bindJSON,
+			"jsonarray": bindJSONArray,
+			"urldecode": bindURLDecode,
+			"unmarshal": bindUnmarshal,
+			"append":    bindAppend,
+		},
+		aliases:       Aliases{},
+		fieldNameFunc: getDefaultFieldNameFunc(),
+
+		sliceSeparator: ",",
+		requiredTagKey: "required",
+	}
+
+	config.bindings["int"] = config.bindInt
+	config.bindings["float"] = config.bindFloat
+	config.bindings["phone"] = config.bindPhone
+
+	for _, option := range options {
+		switch option := option.(type) {
+		case Bindings:
+			for key, binding := range option {
+				config.bindings[key] = binding
+			}
+		case FieldBindings:
+			for key, binding := range option {
+				config.fieldBindings[key] = binding
+			}
+		case TypeBindings:
+			if config.typeBindings == nil {
+				config.typeBindings = TypeBindings{}
+			}
+
+			for key, binding := range option {
+				config.typeBindings[key] = binding
+			}
+		case PhoneNormalizer:
+			config.phoneNormalizer = option
+		case Locales:
+			if config.locales == nil {
+				config.locales = Locales{}
+			}
+
+			for name, locale := range option {
+				config.locales[name] = locale
+			}
+		case PolymorphicTypes:
+			if config.polymorphicTypes == nil {
+				config.polymorphicTypes = PolymorphicTypes{}
+			}
+
+			for name, constructor := range option {
+				config.polymorphicTypes[name] = constructor
+			}
+		case Aliases:
+			for alias, name := range option {
+				config.aliases[alias] = name
+			}
+		case FieldNameFunc:
+			config.fieldNameFunc = option
+		case ReuseSlices:
+			config.reuseSlices = bool(option)
+		case SliceSeparator:
+			config.sliceSeparator = string(option)
+		case SliceGaps:
+			config.sliceGaps = int(option)
+		case RequireTaggedBindings:
+			config.requireTaggedBindings = bool(option)
+		case FailFast:
+			config.failFast = bool(option)
+		case MaxErrors:
+			config.maxErrors = int(option)
+		case NestedMaps:
+			config.nestedMaps = bool(option)
+		case RequireBindableFields:
+			config.requireBindableFields = bool(option)
+		case StrictNames:
+			config.strictNames = bool(option)
+		case EmptyAsAbsent:
+			config.emptyAsAbsent = bool(option)
+		case SkipZero:
+			config.skipZero = bool(option)
+		case IgnoreUnknownBindings:
+			config.ignoreUnknownBindings = bool(option)
+		case SkipUnbindable:
+			config.skipUnbindable = bool(option)
+		case IgnoreUnknownArgs:
+			config.ignoreUnknownArgs = bool(option)
+		case ZeroOnError:
+			config.zeroOnError = bool(option)
+		case TransactionalMaps:
+			config.transactionalMaps = bool(option)
+		case FieldFilter:
+			config.fieldFilter = option
+		case RequiredFunc:
+			config.requiredFunc = option
+		case Compare:
+			config.comparisons = append(config.comparisons, option...)
+		case RequiredTagKey:
+			config.requiredTagKey = string(option)
+		case Prefix:
+			config.prefix = string(option)
+		case KeyFunc:
+			config.keyFunc = option
+		case KeyTransform:
+			config.keyTransform = option
+		case Values:
+			config.values = option
+		case Defaults:
+			if config.defaults == nil {
+				config.defaults = Defaults{}
+			}
+
+			for name, value := range option {
+				config.defaults[name] = value
+			}
+		}
+	}
+
+	config.registerAliases()
+
+	return config
+}
+
+// getName returns name of the field for given direction: tag with given
+// key, `from` for Bind or `to` for Unbind, takes precedence over name
+// returned by FieldNameFunc. Tag value `-` means that field is skipped in
+// that direction.
+func (config *config) getName(field reflect.StructField, key string) string {
+	if name, ok := field.Tag.Lookup(key); ok && name != "" {
+		if name == "-" {
+			return ""
+		}
+
+		return name
+	}
+
+	return config.fieldNameFunc(field)
+}
+
+// isRequired reports whether field is required using RequiredFunc option
+// or tag specified by RequiredTagKey option if there is no such function.
+func (config *config) isRequired(field reflect.StructField) bool {
+	if config.requiredFunc != nil {
+		return config.requiredFunc(field)
+	}
+
+	return isRequired(field, config.requiredTagKey)
+}
+
+// getTypeBinding returns binding registered for type of the field using
+// TypeBindings option. Fields with `binding` tag have no type binding.
+func (config *config) getTypeBinding(
+	field reflect.StructField,
+) (BindFunc, bool) {
+	if field.Tag.Get("binding") != "" {
+		return nil, false
+	}
+
+	binding, ok := config.typeBindings[getBindingType(field.Type)]
+
+	return binding, ok
+}
+
+// resolveAlias follows aliases starting from given alias and returns name of
+// binding it refers to. Error is returned if aliases are circular.
+func resolveAlias(alias string, aliases map[string]string) (string, error) {
+	var (
+		name    = alias
+		visited = map[string]bool{alias: true}
+	)
+
+	for {
+		next, ok := aliases[name]
+		if !ok {
+			return name, nil
+		}
+
+		if visited[next] {
+			return "", InvalidBindingError(
+				fmt.Sprintf("binding alias %q is circular", alias),
+			)
+		}
+
+		visited[next] = true
+		name = next
+	}
+}
+
+// registerAliases registers aliases as bindings or field bindings they refer
+// to. If alias can't be resolved, it's registered as binding which reports
+// InvalidBindingError, so misconfiguration is reported like for any other
+// binding.
+func (config *config) registerAliases() {
+	var (
+		bindings      = Bindings{}
+		fieldBindings = FieldBindings{}
+	)
+
+	for alias := range config.aliases {
+		name, err := resolveAlias(alias, config.aliases)
+
+		if binding, ok := config.fieldBindings[name]; ok && err == nil {
+			fieldBindings[alias] = binding
+
+			continue
+		}
+
+		if binding, ok := config.bindings[name]; ok && err == nil {
+			bindings[alias] = binding
+
+			continue
+		}
+
+		if err == nil {
+			err = InvalidBindingError(
+				fmt.Sprintf(
+					"binding alias %q refers to unknown binding %q",
+					alias,
+					name,
+				),
+			)
+		}
+
+		bindings[alias] = func(interface{}, string) (interface{}, error) {
+			return nil, err
+		}
+	}
+
+	for alias, binding := range bindings {
+		config.bindings[alias] = binding
+	}
+
+	for alias, binding := range fieldBindings {
+		config.fieldBindings[alias] = binding
+	}
+}
+
+// wrapMapper returns mapper which applies Prefix, KeyFunc and KeyTransform
+// options to names before passing them to given mapper, and which reads
+// values from Values option first.
+func (config *config) wrapMapper(mapper MapFunc) MapFunc {
+	if config.values != nil {
+		mapper = getValuesMapper(config.values, mapper)
+	}
+
+	if config.keyTransform != nil {
+		transformed := mapper
+
+		mapper = func(name string) interface{} {
+			return transformed(config.keyTransform(name))
+		}
+	}
+
+	if config.prefix == "" && config.keyFunc == nil {
+		return mapper
+	}
+
+	return func(name string) interface{} {
+		name = config.prefix + name
+
+		if config.keyFunc != nil {
+			name = config.keyFunc(name)
+		}
+
+		return mapper(name)
+	}
+}
+
+// getValuesMapper returns mapper which reads values from given map and
+// falls back to given mapper (if any) for absent names.
+func getValuesMapper(values Values, fallback MapFunc) MapFunc {
+	return func(name string) interface{} {
+		if value, ok := values[name]; ok {
+			return value
+		}
+
+		if fallback != nil {
+			return fallback(name)
+		}
+
+		return nil
+	}
+}
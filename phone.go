@@ -0,0 +1,82 @@
+package binding
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PhoneNormalizer option specifies function which is called by `phone`
+// binding with cleaned phone number, like `+15551234567`, and which returns
+// normalized number, like E.164 formatted one, or error if number is
+// invalid. It's handy to plug full-featured phone libraries in. It's not
+// used if `phone` binding is overridden by Bindings option.
+type PhoneNormalizer func(phone string) (string, error)
+
+// bindPhone is a built-in `phone` binding, which passes cleaned numbers to
+// normalizer specified by PhoneNormalizer option, if any.
+func (config *config) bindPhone(
+	data interface{},
+	opts string,
+) (interface{}, error) {
+	phone, err := bindPhone(data, opts)
+	if err != nil || config.phoneNormalizer == nil {
+		return phone, err
+	}
+
+	return config.phoneNormalizer(phone.(string))
+}
+
+// bindPhone strips formatting characters, like spaces, dashes, dots and
+// parens, from phone number and checks that it has from 7 to 15 digits,
+// optionally prefixed with `+`. Limits can be changed using options, like
+// `phone:10,11`.
+func bindPhone(data interface{}, opts string) (interface{}, error) {
+	var (
+		minDigits = 7
+		maxDigits = 15
+	)
+
+	_, err := fmt.Sscanf(opts, "%d,%d", &minDigits, &maxDigits)
+	if err != nil && !strings.HasSuffix(err.Error(), "EOF") {
+		return nil, InvalidBindingError(err.Error())
+	}
+
+	if _, ok := data.(string); !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("only strings are supported, but %T given", data),
+		)
+	}
+
+	var (
+		phone  = strings.TrimSpace(data.(string))
+		result = strings.Builder{}
+		digits = 0
+	)
+
+	if strings.HasPrefix(phone, "+") {
+		result.WriteByte('+')
+		phone = phone[1:]
+	}
+
+	for _, char := range phone {
+		switch {
+		case char >= '0' && char <= '9':
+			result.WriteRune(char)
+			digits++
+		case strings.ContainsRune(" -.()", char):
+		default:
+			return nil, fmt.Errorf("invalid phone number: %q", data)
+		}
+	}
+
+	if digits < minDigits || digits > maxDigits {
+		return nil, fmt.Errorf(
+			"phone number should have from %d to %d digits, but %d given",
+			minDigits,
+			maxDigits,
+			digits,
+		)
+	}
+
+	return result.String(), nil
+}
@@ -0,0 +1,138 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PolymorphicTypes option specifies constructors of concrete types which
+// can be bound into fields with `polymorphic` binding, like
+// `PolymorphicTypes{"card": func() interface{} { return &Card{} }}`.
+// Constructors should return pointers to structs.
+type PolymorphicTypes map[string]func() interface{}
+
+// isPolymorphicType reports whether field has `polymorphic` binding, like
+// `binding:"polymorphic:Type"`, where option is the name of sibling string
+// field which holds name of concrete type.
+func isPolymorphicType(field reflect.StructField) bool {
+	name, _ := parseBindingTag(field)
+
+	return name == "polymorphic"
+}
+
+// getDiscriminator returns sibling field which is referred by `polymorphic`
+// binding of the field.
+func getDiscriminator(
+	structType reflect.Type,
+	field reflect.StructField,
+) (reflect.StructField, error) {
+	_, name := parseBindingTag(field)
+
+	discriminator, ok := structType.FieldByName(name)
+	if !ok || len(discriminator.Index) != 1 ||
+		discriminator.Type.Kind() != reflect.String {
+		return discriminator, InvalidBindingError(
+			fmt.Sprintf(
+				`polymorphic field %s.%s refers to unknown string field %q`,
+				structType,
+				field.Name,
+				name,
+			),
+		)
+	}
+
+	return discriminator, nil
+}
+
+// bindPolymorphic binds fields with given indices, which have `polymorphic`
+// binding. Concrete type of every field is obtained from sibling field, so
+// it should be called after all other fields of the struct are bound.
+// Values of concrete types are bound as nested structs.
+func (binder *binder) bindPolymorphic(
+	structValue reflect.Value,
+	fields []int,
+	mapper MapFunc,
+	prefix string,
+) error {
+	var (
+		structType = structValue.Type()
+		config     = binder.config
+	)
+
+	for _, i := range fields {
+		if binder.isStopped() {
+			break
+		}
+
+		var (
+			field = structType.Field(i)
+			key   = config.getName(field, "from")
+			name  = prefix + key
+		)
+
+		discriminator, err := getDiscriminator(structType, field)
+		if err != nil {
+			return err
+		}
+
+		typeName := structValue.Field(discriminator.Index[0]).String()
+		if typeName == "" {
+			if config.isRequired(field) {
+				binder.addError(name, RequiredError{name: name})
+			}
+
+			continue
+		}
+
+		constructor, ok := config.polymorphicTypes[typeName]
+		if !ok {
+			binder.addError(name, fmt.Errorf("unknown type %q", typeName))
+
+			continue
+		}
+
+		var (
+			raw   = constructor()
+			value = reflect.ValueOf(raw)
+		)
+
+		if value.Kind() != reflect.Ptr ||
+			value.Elem().Kind() != reflect.Struct {
+			return InvalidBindingError(
+				fmt.Sprintf(
+					`constructor of type %q should return pointer to struct, `+
+						`but %T returned`,
+					typeName,
+					raw,
+				),
+			)
+		}
+
+		target := structValue.Field(i)
+		if !target.CanSet() || !value.Type().AssignableTo(target.Type()) {
+			return InvalidBindingError(
+				fmt.Sprintf(
+					`value of type %q (%s) can't be set into %s.%s`,
+					typeName,
+					value.Type(),
+					structType,
+					field.Name,
+				),
+			)
+		}
+
+		nestedMapper, err := binder.getNestedMapper(mapper, key, name)
+		if err != nil {
+			return err
+		}
+
+		_, err = binder.bindStruct(value.Elem(), nestedMapper, name+".")
+		if err != nil {
+			return err
+		}
+
+		target.Set(value)
+	}
+
+	return nil
+}
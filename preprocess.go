@@ -0,0 +1,92 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// getCoercionTable parses `coerce` tag of the field, which specifies
+// replacements of mapped values in the form of
+// `coerce:"<value>=<replacement>,..."`, like `coerce:"Y=true,N=false"`.
+func getCoercionTable(field reflect.StructField) (map[string]string, error) {
+	tag, ok := field.Tag.Lookup("coerce")
+	if !ok {
+		return nil, nil
+	}
+
+	table := map[string]string{}
+
+	for _, entry := range strings.Split(tag, ",") {
+		pair := strings.SplitN(entry, "=", 2)
+		if len(pair) != 2 {
+			return nil, InvalidBindingError(
+				fmt.Sprintf(
+					`invalid coerce entry %q of field %s`,
+					entry,
+					field.Name,
+				),
+			)
+		}
+
+		table[pair[0]] = pair[1]
+	}
+
+	return table, nil
+}
+
+// trim removes leading and trailing whitespace from the value if field has
+// `trim` tag. Tag can be either bool, like `trim:"true"`, or list of chars
+// which are removed in addition to whitespace, like `trim:"$"`.
+func trim(field reflect.StructField, value string) string {
+	tag, ok := field.Tag.Lookup("trim")
+	if !ok {
+		return value
+	}
+
+	if enabled, err := strconv.ParseBool(tag); err == nil {
+		if enabled {
+			return strings.TrimSpace(value)
+		}
+
+		return value
+	}
+
+	return strings.Trim(value, tag+" \t\r\n\v\f")
+}
+
+// preprocess wraps binding, so string values are trimmed according to
+// `trim` tag and then replaced according to `coerce` tag of the field before
+// they are passed to binding.
+func preprocess(
+	field reflect.StructField,
+	binding func(interface{}) (interface{}, error),
+) func(interface{}) (interface{}, error) {
+	table, err := getCoercionTable(field)
+	if err != nil {
+		return func(interface{}) (interface{}, error) {
+			return nil, err
+		}
+	}
+
+	_, trimmed := field.Tag.Lookup("trim")
+
+	if table == nil && !trimmed {
+		return binding
+	}
+
+	return func(data interface{}) (interface{}, error) {
+		if value, ok := data.(string); ok {
+			value = trim(field, value)
+
+			if replacement, ok := table[value]; ok {
+				value = replacement
+			}
+
+			data = value
+		}
+
+		return binding(data)
+	}
+}
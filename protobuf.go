@@ -0,0 +1,44 @@
+package binding
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ProtobufFieldNumber is a FieldNameFunc which uses protobuf field number as
+// field name. Field number is the second comma-separated segment of
+// `protobuf` tag generated by protoc, like `protobuf:"varint,1,opt,name=id"`.
+// Fields without valid `protobuf` tag are skipped.
+//
+// It's intended to be used with NumberedMapper.
+func ProtobufFieldNumber(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("protobuf")
+	if !ok {
+		return ""
+	}
+
+	segments := strings.Split(tag, ",")
+	if len(segments) < 2 {
+		return ""
+	}
+
+	if _, err := strconv.Atoi(segments[1]); err != nil {
+		return ""
+	}
+
+	return segments[1]
+}
+
+// NumberedMapper returns mapper which obtains values from map keyed by
+// numbers, like protobuf field numbers returned by ProtobufFieldNumber.
+func NumberedMapper(values map[int]interface{}) MapFunc {
+	return func(name string) interface{} {
+		number, err := strconv.Atoi(name)
+		if err != nil {
+			return nil
+		}
+
+		return values[number]
+	}
+}
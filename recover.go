@@ -0,0 +1,42 @@
+package binding
+
+import (
+	"fmt"
+)
+
+// recoverBinding wraps binding of the field with given name, so panic in
+// binding, like custom one with a bug, is returned as InvalidBindingError
+// instead of crashing the caller.
+func recoverBinding(
+	name string,
+	binding func(interface{}) (interface{}, error),
+) func(interface{}) (interface{}, error) {
+	return func(data interface{}) (result interface{}, err error) {
+		defer func() {
+			if reason := recover(); reason != nil {
+				result, err = nil, getPanicError(name, reason)
+			}
+		}()
+
+		return binding(data)
+	}
+}
+
+// recoverFieldBinding works like recoverBinding, but for field bindings.
+func recoverFieldBinding(binding FieldBindFunc) FieldBindFunc {
+	return func(field Field, opts string) (result interface{}, err error) {
+		defer func() {
+			if reason := recover(); reason != nil {
+				result, err = nil, getPanicError(field.Name, reason)
+			}
+		}()
+
+		return binding(field, opts)
+	}
+}
+
+func getPanicError(name string, reason interface{}) InvalidBindingError {
+	return InvalidBindingError(
+		fmt.Sprintf("binding of %s panicked: %v", name, reason),
+	)
+}
@@ -0,0 +1,50 @@
+package binding
+
+// BindReport describes what was done by BindWithReport.
+type BindReport struct {
+	// Values contains values of fields which were set from mapped values,
+	// keyed by field name. Fields which were absent or failed to bind are
+	// not listed.
+	Values map[string]interface{}
+
+	// Provenance describes where values listed in Values came from, keyed
+	// by field name.
+	Provenance map[string]Provenance
+
+	// Warnings lists non-fatal issues which occurred while binding fields
+	// listed in Values, like usage of binding aliases or numbers which were
+	// reformatted according to `locale` option. Warnings never cause Bind
+	// to fail.
+	Warnings []Warning
+}
+
+// Provenance describes raw mapped value which was used to set field.
+type Provenance struct {
+	// Name is a field name.
+	Name string
+
+	// RawValue is a value returned by mapper before binding.
+	RawValue interface{}
+
+	// Binding is a binding with options which was used to parse RawValue,
+	// like `int:8`.
+	Binding string
+}
+
+// BindWithReport works like Bind, but also returns report about bound
+// fields and non-fatal warnings. Report is returned even if binding errors
+// occurred.
+func BindWithReport(
+	output interface{},
+	mapper MapFunc,
+	options ...interface{},
+) (*BindReport, error) {
+	report := &BindReport{
+		Values:     map[string]interface{}{},
+		Provenance: map[string]Provenance{},
+	}
+
+	err := bind(output, mapper, newConfig(options), report)
+
+	return report, err
+}
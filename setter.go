@@ -0,0 +1,137 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// FieldSetter can be implemented by output to take control over storing
+// bound values, which allows to bind into dynamic targets, like ones backed
+// by sync.Map, instead of structs.
+type FieldSetter interface {
+	// FieldNames returns names of fields which should be obtained from
+	// mapper.
+	FieldNames() []string
+
+	// SetField stores mapped value of field with given name. It's not called
+	// for fields which have no mapped value.
+	SetField(name string, value interface{}) error
+}
+
+func bindFieldSetter(setter FieldSetter, mapper MapFunc, config *config) error {
+	var (
+		binder = &binder{config: config}
+		mapped = config.wrapMapper(mapper)
+	)
+
+	for _, name := range setter.FieldNames() {
+		if binder.isStopped() {
+			break
+		}
+
+		data := mapped(name)
+
+		if data == "" && config.emptyAsAbsent {
+			data = nil
+		}
+
+		if data == nil {
+			continue
+		}
+
+		err := setter.SetField(name, data)
+		if err, ok := err.(InvalidBindingError); ok {
+			return err
+		}
+
+		if err != nil {
+			binder.addError(name, err)
+		}
+	}
+
+	return binder.result()
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// getSetter returns method of the struct specified by `setter` tag of the
+// field, like `setter:"SetEmail"`, Store method of atomic fields, or invalid
+// value if there is neither. Method should accept single argument and return
+// either nothing or error.
+func getSetter(
+	structValue reflect.Value,
+	field reflect.StructField,
+) (reflect.Value, error) {
+	name, ok := field.Tag.Lookup("setter")
+	if !ok {
+		if isAtomicType(field.Type) {
+			return getAtomicStore(structValue, field)
+		}
+
+		return reflect.Value{}, nil
+	}
+
+	if !structValue.CanAddr() {
+		return reflect.Value{}, InvalidBindingError(
+			fmt.Sprintf(
+				`setter of %s.%s can't be called on unaddressable struct`,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	method := structValue.Addr().MethodByName(name)
+	if !method.IsValid() {
+		return reflect.Value{}, InvalidBindingError(
+			fmt.Sprintf(
+				`setter %s of %s.%s is not found`,
+				name,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	methodType := method.Type()
+	if methodType.NumIn() != 1 || methodType.NumOut() > 1 ||
+		methodType.NumOut() == 1 && methodType.Out(0) != errorType {
+		return reflect.Value{}, InvalidBindingError(
+			fmt.Sprintf(
+				`setter %s of %s.%s should accept single argument `+
+					`and return nothing or error`,
+				name,
+				structValue.Type(),
+				field.Name,
+			),
+		)
+	}
+
+	return method, nil
+}
+
+// getSetterType returns type of the value which is passed to setter. Values
+// for setters accepting interfaces, like atomic.Value.Store, are bound as
+// strings unless field has `binding` tag.
+func getSetterType(
+	field reflect.StructField,
+	setter reflect.Value,
+) reflect.Type {
+	argType := setter.Type().In(0)
+	if argType.Kind() == reflect.Interface && field.Tag.Get("binding") == "" {
+		return reflect.TypeOf("")
+	}
+
+	return argType
+}
+
+// callSetter calls setter with given value and returns error returned by
+// setter, if any.
+func callSetter(setter reflect.Value, value reflect.Value) error {
+	results := setter.Call([]reflect.Value{value})
+	if len(results) == 0 || results[0].IsNil() {
+		return nil
+	}
+
+	return results[0].Interface().(error)
+}
@@ -0,0 +1,139 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// isSliceType reports whether field of given type should be bound element by
+// element. Byte slices are treated as scalar values.
+func isSliceType(fieldType reflect.Type) bool {
+	return fieldType.Kind() == reflect.Slice &&
+		fieldType.Elem().Kind() != reflect.Uint8
+}
+
+// splitSeparatorOption extracts `sep=<separator>` option from binding
+// options. Since separator can contain any chars, it should be the last
+// option.
+func splitSeparatorOption(opts string) (string, string, bool) {
+	if strings.HasPrefix(opts, "sep=") {
+		return "", strings.TrimPrefix(opts, "sep="), true
+	}
+
+	if index := strings.Index(opts, ";sep="); index >= 0 {
+		return opts[:index], opts[index+len(";sep="):], true
+	}
+
+	return opts, "", false
+}
+
+// getSeparator returns separator specified in field binding tag or
+// defaultSeparator if there is none.
+func getSeparator(field reflect.StructField, defaultSeparator string) string {
+	_, opts := parseBindingTag(field)
+
+	if _, separator, ok := splitSeparatorOption(opts); ok {
+		return separator
+	}
+
+	return defaultSeparator
+}
+
+// getItemsLimits returns limits of number of slice elements specified by
+// `minitems` and `maxitems` tags of the field. Zero means no limit.
+func getItemsLimits(field reflect.StructField) (int, int, error) {
+	var limits [2]int
+
+	for i, key := range []string{"minitems", "maxitems"} {
+		value, ok := field.Tag.Lookup(key)
+		if !ok {
+			continue
+		}
+
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return 0, 0, InvalidBindingError(
+				fmt.Sprintf(
+					`%s tag of %s should be non-negative int, but %q given`,
+					key,
+					field.Name,
+					value,
+				),
+			)
+		}
+
+		limits[i] = limit
+	}
+
+	return limits[0], limits[1], nil
+}
+
+func getSliceItems(data interface{}, separator string) ([]string, bool) {
+	switch data := data.(type) {
+	case []string:
+		return data, true
+	case string:
+		if data == "" {
+			return []string{}, true
+		}
+
+		return strings.Split(data, separator), true
+	default:
+		return nil, false
+	}
+}
+
+func bindSlice(
+	target reflect.Value,
+	name string,
+	items []string,
+	binding func(interface{}) (interface{}, error),
+	reuse bool,
+) (BindingErrors, error) {
+	var slice reflect.Value
+
+	if reuse && !target.IsNil() && target.Cap() >= len(items) {
+		slice = target.Slice(0, len(items))
+	} else {
+		slice = reflect.MakeSlice(target.Type(), len(items), len(items))
+	}
+
+	var errors BindingErrors
+
+	for i, item := range items {
+		value, err := binding(item)
+		if err, ok := err.(InvalidBindingError); ok {
+			return nil, err
+		}
+
+		if err != nil {
+			errors = append(errors, BindingError{
+				name:  fmt.Sprintf("%s[%d]", name, i),
+				cause: err,
+			})
+
+			continue
+		}
+
+		if !setValue(slice.Index(i), value) {
+			return nil, InvalidBindingError(
+				fmt.Sprintf(
+					`binding of %s returned %T, which can't be set into %s`,
+					name,
+					value,
+					slice.Type().Elem(),
+				),
+			)
+		}
+	}
+
+	if len(errors) > 0 {
+		return errors, nil
+	}
+
+	target.Set(slice)
+
+	return nil, nil
+}
@@ -0,0 +1,166 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Unbind does the opposite to Bind: it returns values of exported struct
+// fields formatted as strings and keyed by the same names which Bind passes
+// to mapper function, except that `to` tag is used instead of `from` tag, so
+// names are resolved in following order: `to` tag, then FieldNameFunc, and
+// then field's name.
+//
+// Slice fields are joined using separator which is specified in the same way
+// as for Bind. Nil pointer fields are not included into result. Fields of
+// nested structs and slices of structs are flattened into the same keys
+// which Bind reads, like `Address.City` or `Items[0].Name`.
+//
+// If tag which specifies field name has `omitempty` option, like
+// `json:"name,omitempty"`, then field will not be included into result if it
+// has zero value, or if it's type implements Emptier and IsEmpty returns
+// true.
+//
+// Options which are accepted by Bind can be passed, but only FieldNameFunc,
+// TypeBindings, SliceSeparator, Prefix, KeyFunc and KeyTransform affect
+// Unbind.
+func Unbind(
+	input interface{},
+	options ...interface{},
+) (map[string]string, error) {
+	config := newConfig(options)
+
+	structValue := reflect.Indirect(reflect.ValueOf(input))
+	if !structValue.IsValid() || structValue.Kind() != reflect.Struct {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(`input should be struct type, but %T is given`, input),
+		)
+	}
+
+	values := map[string]string{}
+
+	unbindStruct(structValue, config, "", values)
+
+	result := map[string]string{}
+
+	for name, value := range values {
+		name = config.prefix + name
+
+		if config.keyFunc != nil {
+			name = config.keyFunc(name)
+		}
+
+		if config.keyTransform != nil {
+			name = config.keyTransform(name)
+		}
+
+		result[name] = value
+	}
+
+	return result, nil
+}
+
+// unbindStruct stores formatted values of fields of given struct into result
+// using names with given prefix.
+func unbindStruct(
+	structValue reflect.Value,
+	config *config,
+	prefix string,
+	result map[string]string,
+) {
+	structType := structValue.Type()
+
+	for i := 0; i < structType.NumField(); i++ {
+		var (
+			field = structType.Field(i)
+			key   = config.getName(field, "to")
+			name  = prefix + key
+			value = structValue.Field(i)
+		)
+
+		if key == "" || field.PkgPath != "" {
+			continue
+		}
+
+		if hasOmitEmpty(field) && isEmpty(value) {
+			continue
+		}
+
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+
+			value = value.Elem()
+		}
+
+		_, hasTypeBinding := config.getTypeBinding(field)
+
+		if isNestedType(field) && !hasTypeBinding &&
+			!isStringerType(value.Type()) {
+			unbindStruct(value, config, name+".", result)
+
+			continue
+		}
+
+		if isNestedSliceType(field) && !hasTypeBinding &&
+			!isStringerType(getBindingType(field.Type)) {
+			for index := 0; index < value.Len(); index++ {
+				item := reflect.Indirect(value.Index(index))
+				if !item.IsValid() {
+					continue
+				}
+
+				itemPrefix := fmt.Sprintf("%s[%d].", name, index)
+
+				unbindStruct(item, config, itemPrefix, result)
+			}
+
+			continue
+		}
+
+		if isSliceType(value.Type()) {
+			items := make([]string, value.Len())
+			for i := range items {
+				items[i] = fmt.Sprint(value.Index(i).Interface())
+			}
+
+			result[name] = strings.Join(
+				items,
+				getSeparator(field, config.sliceSeparator),
+			)
+
+			continue
+		}
+
+		result[name] = fmt.Sprint(value.Interface())
+	}
+}
+
+// isStringerType reports whether pointer to value of given type implements
+// fmt.Stringer, so value is formatted as a whole instead of being flattened,
+// like time.Time.
+func isStringerType(valueType reflect.Type) bool {
+	stringerType := reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+	return reflect.PtrTo(valueType).Implements(stringerType)
+}
+
+// hasOmitEmpty reports whether first tag which can specify field name has
+// `omitempty` option.
+func hasOmitEmpty(field reflect.StructField) bool {
+	for _, key := range fieldNameTags {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			for _, option := range strings.Split(tag, ",")[1:] {
+				if option == "omitempty" {
+					return true
+				}
+			}
+
+			return false
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package binding
+
+import (
+	"encoding"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+)
+
+var (
+	binaryUnmarshalerType = reflect.TypeOf(
+		(*encoding.BinaryUnmarshaler)(nil),
+	).Elem()
+
+	textUnmarshalerType = reflect.TypeOf(
+		(*encoding.TextUnmarshaler)(nil),
+	).Elem()
+)
+
+// isUnmarshalerType reports whether pointer to the value of given type (or
+// type itself, if it's pointer) implements encoding.BinaryUnmarshaler or
+// encoding.TextUnmarshaler.
+func isUnmarshalerType(fieldType reflect.Type) bool {
+	if fieldType.Kind() != reflect.Ptr {
+		fieldType = reflect.PtrTo(fieldType)
+	}
+
+	return fieldType.Implements(binaryUnmarshalerType) ||
+		fieldType.Implements(textUnmarshalerType)
+}
+
+// isOpaqueType reports whether given type is a struct which has no exported
+// fields and implements unmarshaler interface, like netip.Addr, so it can't
+// be bound as nested struct.
+func isOpaqueType(structType reflect.Type) bool {
+	return !hasExportedFields(structType) && isUnmarshalerType(structType)
+}
+
+// bindUnmarshal binds field which type implements encoding.BinaryUnmarshaler
+// or encoding.TextUnmarshaler. Byte slices are passed to UnmarshalBinary and
+// strings to UnmarshalText, falling back to other method if type implements
+// only one of them. Strings can be decoded into bytes first using `base64`
+// or `hex` option, like `unmarshal:base64`.
+func bindUnmarshal(field Field, opts string) (interface{}, error) {
+	if !isUnmarshalerType(field.StructField.Type) {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"unmarshal binding of %s requires type implementing "+
+					"encoding.BinaryUnmarshaler or encoding.TextUnmarshaler",
+				field.Name,
+			),
+		)
+	}
+
+	if opts != "" && opts != "base64" && opts != "hex" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("unknown unmarshal option: %q", opts),
+		)
+	}
+
+	if field.Value == nil {
+		return nil, nil
+	}
+
+	var (
+		data   []byte
+		binary bool
+	)
+
+	switch value := field.Value.(type) {
+	case []byte:
+		data, binary = value, true
+	case string:
+		data = []byte(value)
+	default:
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"only strings and byte slices are supported, but %T given",
+				field.Value,
+			),
+		)
+	}
+
+	if !binary && opts != "" {
+		var err error
+
+		data, err = decodeBytes(string(data), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		binary = true
+	}
+
+	fieldType := field.StructField.Type
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	var (
+		target     = reflect.New(fieldType)
+		binaryType = target.Type().Implements(binaryUnmarshalerType)
+		textType   = target.Type().Implements(textUnmarshalerType)
+		err        error
+	)
+
+	if binaryType && (binary || !textType) {
+		err = target.Interface().(encoding.BinaryUnmarshaler).
+			UnmarshalBinary(data)
+	} else {
+		err = target.Interface().(encoding.TextUnmarshaler).
+			UnmarshalText(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return target.Elem().Interface(), nil
+}
+
+// decodeBytes decodes string using given encoding, which is either `base64`
+// (standard or URL-safe, padded or not) or `hex`.
+func decodeBytes(data string, encoding string) ([]byte, error) {
+	if encoding == "hex" {
+		return hex.DecodeString(data)
+	}
+
+	for _, encoding := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.URLEncoding,
+		base64.RawStdEncoding,
+		base64.RawURLEncoding,
+	} {
+		result, err := encoding.DecodeString(data)
+		if err == nil {
+			return result, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid base64 string: %q", data)
+}
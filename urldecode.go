@@ -0,0 +1,62 @@
+package binding
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// bindURLDecode unescapes percent-encoded mapped string, like `a%20b`, using
+// url.QueryUnescape and passes result to underlying binding, which is
+// specified as option, like `urldecode:int`. If option is empty, default
+// binding for the field type is used. It's handy for hand-parsed query
+// strings, since url.Values are already unescaped.
+func bindURLDecode(field Field, opts string) (interface{}, error) {
+	spec := opts
+	if spec == "" {
+		spec = getDefaultBindingTag(getBindingType(field.StructField.Type))
+	}
+
+	if spec == "" {
+		return nil, InvalidBindingError(
+			fmt.Sprintf("binding for %s is not specified", field.Name),
+		)
+	}
+
+	name, options := parseBindingTag(reflect.StructField{
+		Tag: reflect.StructTag(`binding:` + strconv.Quote(spec)),
+	})
+
+	binding, ok := field.Bindings[name]
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"binding %q for %s is not registered",
+				name,
+				field.Name,
+			),
+		)
+	}
+
+	if field.Value == nil {
+		return nil, nil
+	}
+
+	data, ok := field.Value.(string)
+	if !ok {
+		return nil, InvalidBindingError(
+			fmt.Sprintf(
+				"only strings are supported, but %T given",
+				field.Value,
+			),
+		)
+	}
+
+	value, err := url.QueryUnescape(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return binding(value, options)
+}
@@ -0,0 +1,86 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// validateField runs post-binding validations specified by field tags
+// against bound value.
+func validateField(
+	field reflect.StructField,
+	name string,
+	value reflect.Value,
+) error {
+	if err := validateOneOf(field, name, value); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateOneOf checks that bound value is one of values listed in `oneof`
+// tag. Pointers are dereferenced and every element of slices is checked.
+func validateOneOf(
+	field reflect.StructField,
+	name string,
+	value reflect.Value,
+) error {
+	tag, ok := field.Tag.Lookup("oneof")
+	if !ok {
+		return nil
+	}
+
+	values := strings.Fields(tag)
+
+	if !isOneOf(value, values) {
+		return OneOfError{name: name, values: values}
+	}
+
+	return nil
+}
+
+func isOneOf(value reflect.Value, values []string) bool {
+	switch value.Kind() {
+	case reflect.Ptr:
+		return value.IsNil() || isOneOf(value.Elem(), values)
+	case reflect.Slice:
+		for i := 0; i < value.Len(); i++ {
+			if !isOneOf(value.Index(i), values) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	actual := fmt.Sprint(value.Interface())
+
+	for _, allowed := range values {
+		if actual == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isOneOfType reports whether values of given type can be checked against
+// `oneof` tag: they should be strings, bools or numbers, or pointers or
+// slices of them.
+func isOneOfType(valueType reflect.Type) bool {
+	for valueType.Kind() == reflect.Ptr || valueType.Kind() == reflect.Slice {
+		valueType = valueType.Elem()
+	}
+
+	switch valueType.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,91 @@
+package binding
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Warning describes non-fatal issue which occurred while binding field, like
+// usage of binding alias. Warnings are listed in BindReport.
+type Warning struct {
+	// Field is a field name.
+	Field string
+
+	// Message describes the issue.
+	Message string
+}
+
+func (warning Warning) String() string {
+	return warning.Field + " — " + warning.Message
+}
+
+// getWarnings returns warnings about binding of given mapped data into
+// field. Following issues are reported: usage of binding aliases and numbers
+// which were reformatted according to `locale` option.
+func getWarnings(
+	name string,
+	field reflect.StructField,
+	data interface{},
+	config *config,
+) []Warning {
+	var (
+		bindingName, opts = parseBindingTag(field)
+		warnings          []Warning
+	)
+
+	if target, ok := config.aliases[bindingName]; ok {
+		warnings = append(warnings, Warning{
+			Field: name,
+			Message: fmt.Sprintf(
+				"binding %q is an alias of %q",
+				bindingName,
+				target,
+			),
+		})
+	}
+
+	opts, _, _ = splitSeparatorOption(opts)
+
+	_, options := parseOptions(opts)
+	if options["locale"] == "" {
+		return warnings
+	}
+
+	items := []string{}
+
+	switch data := data.(type) {
+	case string:
+		items = []string{data}
+		if isSliceType(field.Type) {
+			items, _ = getSliceItems(
+				data,
+				getSeparator(field, config.sliceSeparator),
+			)
+		}
+	case []string:
+		items = data
+	}
+
+	for _, item := range items {
+		normalized, err := normalizeNumber(
+			item,
+			options["locale"],
+			config.locales,
+		)
+		if err != nil || normalized == item {
+			continue
+		}
+
+		warnings = append(warnings, Warning{
+			Field: name,
+			Message: fmt.Sprintf(
+				"%q is normalized to %q using locale %q",
+				item,
+				normalized,
+				options["locale"],
+			),
+		})
+	}
+
+	return warnings
+}
@@ -0,0 +1,73 @@
+package binding
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// XMLNode is a generic XML element, which can be obtained using
+// xml.Unmarshal and bound using BindXMLNode.
+type XMLNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Content  string     `xml:",chardata"`
+	Children []XMLNode  `xml:",any"`
+}
+
+// BindXMLNode binds attributes and child elements of given XML element into
+// output like Bind does, using XMLNodeMapper.
+func BindXMLNode(
+	output interface{},
+	node XMLNode,
+	options ...interface{},
+) error {
+	return Bind(output, XMLNodeMapper(node), options...)
+}
+
+// XMLNodeMapper returns mapper which looks up values in given XML element by
+// local names: attribute with given name is looked up first, then text
+// content of child elements. If there are several child elements with the
+// same name, their contents are returned as []string. Dotted names of nested
+// fields, like `Address.City`, are looked up in nested child elements.
+func XMLNodeMapper(node XMLNode) MapFunc {
+	return func(name string) interface{} {
+		return node.lookup(strings.Split(name, "."))
+	}
+}
+
+func (node XMLNode) lookup(path []string) interface{} {
+	name := path[0]
+
+	if len(path) > 1 {
+		for _, child := range node.Children {
+			if child.XMLName.Local == name {
+				return child.lookup(path[1:])
+			}
+		}
+
+		return nil
+	}
+
+	for _, attr := range node.Attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	var values []string
+
+	for _, child := range node.Children {
+		if child.XMLName.Local == name {
+			values = append(values, strings.TrimSpace(child.Content))
+		}
+	}
+
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}